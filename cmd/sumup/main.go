@@ -9,10 +9,13 @@ import (
 
 	"github.com/sumup/sumup-cli/internal/app"
 	"github.com/sumup/sumup-cli/internal/commands"
+	"github.com/sumup/sumup-cli/internal/commands/alias"
+	"github.com/sumup/sumup-cli/internal/commands/plugin"
 	"github.com/sumup/sumup-cli/internal/display/message"
 )
 
 func main() {
+	pluginArgs := 1
 	cliApp := &cli.Command{
 		Name:                  "sumup",
 		Usage:                 "Command line tool for the SumUp API",
@@ -37,14 +40,25 @@ func main() {
 				Name:  "exact-timestamps",
 				Usage: "Show timestamp fields using the exact local time instead of relative strings.",
 			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Print the API request a mutating command would send without sending it.",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Skip confirmation prompts for destructive commands. Required in non-interactive use.",
+			},
 		},
-		Metadata: map[string]any{},
+		Metadata: map[string]any{app.ArgsKey: os.Args[1:]},
 		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
 			appCtx, err := app.NewContext(
 				cmd.String("api-key"),
 				cmd.String("base-url"),
 				cmd.Bool("json"),
 				cmd.Bool("exact-timestamps"),
+				cmd.Bool("dry-run"),
+				cmd.Bool("yes"),
 			)
 			if err != nil {
 				return ctx, err
@@ -53,9 +67,19 @@ func main() {
 			return ctx, nil
 		},
 		Commands: commands.All(),
+		// Unknown subcommands are dispatched to sumup-<name> plugins, which
+		// parse their own flags.
+		StopOnNthArg: &pluginArgs,
+		Action:       plugin.Run,
+	}
+
+	args, err := alias.Expand(cliApp, os.Args)
+	if err != nil {
+		message.Error("%v", err)
+		os.Exit(1)
 	}
 
-	if err := cliApp.Run(context.Background(), os.Args); err != nil {
+	if err := cliApp.Run(context.Background(), args); err != nil {
 		message.Error("%v", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,192 @@
+// Package accounting turns SumUp activity into journal entries and writes
+// them in formats that bookkeeping software can import.
+package accounting
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+)
+
+// Export formats.
+const (
+	FormatDATEV      = "datev"
+	FormatXero       = "xero"
+	FormatQuickBooks = "quickbooks"
+	FormatOFX        = "ofx"
+)
+
+// Kinds of journal entries.
+const (
+	KindSale       = "sale"
+	KindRefund     = "refund"
+	KindChargeback = "chargeback"
+	KindFee        = "fee"
+	KindPayout     = "payout"
+)
+
+// Entry is a journal entry moving Amount from the Credit to the Debit
+// account. Amount is never negative, the accounts carry the direction.
+type Entry struct {
+	Date        time.Time       `json:"date"`
+	Kind        string          `json:"kind"`
+	Reference   string          `json:"reference"`
+	Description string          `json:"description"`
+	Amount      decimal.Decimal `json:"amount"`
+	Currency    string          `json:"currency"`
+	Debit       string          `json:"debit_account"`
+	Credit      string          `json:"credit_account"`
+	// TaxCode applies to the income or expense side of the entry.
+	TaxCode string `json:"tax_code,omitempty"`
+}
+
+// Mapping assigns accounts and tax codes to the entries. The defaults follow
+// the German SKR03 chart of accounts.
+type Mapping struct {
+	Accounts struct {
+		// Clearing is the account for money held by SumUp until it is paid
+		// out, such as 1360 "Geldtransit" in SKR03.
+		Clearing    string `yaml:"clearing"`
+		Bank        string `yaml:"bank"`
+		Sales       string `yaml:"sales"`
+		Refunds     string `yaml:"refunds"`
+		Chargebacks string `yaml:"chargebacks"`
+		Fees        string `yaml:"fees"`
+	} `yaml:"accounts"`
+	TaxCodes struct {
+		Sales       string `yaml:"sales"`
+		Refunds     string `yaml:"refunds"`
+		Chargebacks string `yaml:"chargebacks"`
+		Fees        string `yaml:"fees"`
+		// None is used for the clearing and bank side of entries, for
+		// tools like Xero that want a tax rate on every line.
+		None string `yaml:"none"`
+	} `yaml:"tax_codes"`
+	// DateFormat is one of YYYY-MM-DD, DD/MM/YYYY and MM/DD/YYYY and is used
+	// by the Xero and QuickBooks exports.
+	DateFormat string `yaml:"date_format"`
+	DATEV      struct {
+		ConsultantNumber     int `yaml:"consultant_number"`
+		ClientNumber         int `yaml:"client_number"`
+		FiscalYearStartMonth int `yaml:"fiscal_year_start_month"`
+		AccountLength        int `yaml:"account_length"`
+	} `yaml:"datev"`
+}
+
+// now returns the current time for the creation dates in the exports.
+var now = time.Now
+
+// dateFormats maps the date formats of the mapping file to Go layouts.
+var dateFormats = map[string]string{
+	"YYYY-MM-DD": "2006-01-02",
+	"DD/MM/YYYY": "02/01/2006",
+	"MM/DD/YYYY": "01/02/2006",
+}
+
+// Formats returns the supported export formats.
+func Formats() []string {
+	return []string{FormatDATEV, FormatXero, FormatQuickBooks, FormatOFX}
+}
+
+// DefaultMapping returns the mapping used without a mapping file.
+func DefaultMapping() Mapping {
+	var mapping Mapping
+	mapping.Accounts.Clearing = "1360"
+	mapping.Accounts.Bank = "1200"
+	mapping.Accounts.Sales = "8400"
+	mapping.Accounts.Refunds = "8400"
+	mapping.Accounts.Chargebacks = "8400"
+	mapping.Accounts.Fees = "4970"
+	mapping.DATEV.FiscalYearStartMonth = 1
+	mapping.DATEV.AccountLength = 4
+	return mapping
+}
+
+// LoadMapping reads a mapping file, falling back to the defaults for the
+// values it does not set. An empty path returns the defaults.
+func LoadMapping(path string) (Mapping, error) {
+	mapping := DefaultMapping()
+	if path == "" {
+		return mapping, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return mapping, fmt.Errorf("read mapping: %w", err)
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&mapping); err != nil && !errors.Is(err, io.EOF) {
+		return mapping, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	if mapping.DateFormat != "" {
+		if _, ok := dateFormats[strings.ToUpper(mapping.DateFormat)]; !ok {
+			return mapping, fmt.Errorf("%s: unsupported date_format %q, expected YYYY-MM-DD, DD/MM/YYYY or MM/DD/YYYY", path, mapping.DateFormat)
+		}
+	}
+	if month := mapping.DATEV.FiscalYearStartMonth; month < 1 || month > 12 {
+		return mapping, fmt.Errorf("%s: datev.fiscal_year_start_month must be between 1 and 12", path)
+	}
+	return mapping, nil
+}
+
+// Period is the range of days covered by an export, both inclusive.
+type Period struct {
+	From time.Time
+	To   time.Time
+}
+
+// Check reports settings the format needs that are missing from the
+// mapping, and periods the format cannot hold.
+func (m Mapping) Check(format string, period Period) error {
+	if format != FormatDATEV {
+		return nil
+	}
+	if m.DATEV.ConsultantNumber == 0 || m.DATEV.ClientNumber == 0 {
+		return errors.New("DATEV exports need datev.consultant_number and datev.client_number in the mapping file")
+	}
+	fiscalYear := fiscalYearStart(period.From, m.DATEV.FiscalYearStartMonth)
+	if !period.To.Before(fiscalYear.AddDate(1, 0, 0)) {
+		return fmt.Errorf("a DATEV batch cannot span fiscal years, export until %s and from %s separately",
+			fiscalYear.AddDate(1, 0, -1).Format(time.DateOnly), fiscalYear.AddDate(1, 0, 0).Format(time.DateOnly))
+	}
+	return nil
+}
+
+// Write writes the entries in the given format. The mapping has to pass
+// Check for the format and period first.
+func Write(w io.Writer, format string, mapping Mapping, period Period, entries []Entry) error {
+	switch format {
+	case FormatDATEV:
+		return writeDATEV(w, mapping, period, entries)
+	case FormatXero:
+		return writeXero(w, mapping, entries)
+	case FormatQuickBooks:
+		return writeQuickBooks(w, mapping, entries)
+	case FormatOFX:
+		return writeOFX(w, mapping, period, entries)
+	default:
+		return fmt.Errorf("unsupported format %q, expected one of %s", format, strings.Join(Formats(), ", "))
+	}
+}
+
+func (m Mapping) dateLayout(fallback string) string {
+	if layout, ok := dateFormats[strings.ToUpper(m.DateFormat)]; ok {
+		return layout
+	}
+	return dateFormats[fallback]
+}
+
+// isIncomeOrExpense reports whether the account is the profit and loss side
+// of an entry, which carries the tax code.
+func (m Mapping) isIncomeOrExpense(account string) bool {
+	return account != m.Accounts.Clearing && account != m.Accounts.Bank
+}
@@ -0,0 +1,187 @@
+package accounting
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func TestWrite(t *testing.T) {
+	now = func() time.Time { return time.Date(2026, 11, 2, 9, 30, 0, 0, time.UTC) }
+	t.Cleanup(func() { now = time.Now })
+
+	mapping := DefaultMapping()
+	mapping.TaxCodes.Sales = "3"
+	mapping.TaxCodes.Refunds = "3"
+	mapping.TaxCodes.None = "NONE"
+	mapping.DATEV.ConsultantNumber = 1001
+	mapping.DATEV.ClientNumber = 20002
+	period := Period{From: day(2026, 10, 1), To: day(2026, 10, 31)}
+
+	tests := []struct {
+		format  string
+		mapping func(Mapping) Mapping
+		entries []Entry
+	}{
+		{format: FormatDATEV, entries: testEntries(mapping)},
+		{format: FormatXero, entries: testEntries(mapping)},
+		{
+			format: FormatQuickBooks,
+			mapping: func(m Mapping) Mapping {
+				m.DateFormat = "YYYY-MM-DD"
+				return m
+			},
+			entries: testEntries(mapping),
+		},
+		{
+			format: FormatOFX,
+			entries: append(testEntries(mapping), Entry{
+				Date: day(2026, 10, 20), Kind: KindSale, Reference: "TGBP1", Description: "Coffee & cake",
+				Amount: decimal.RequireFromString("3.20"), Currency: "GBP",
+				Debit: mapping.Accounts.Clearing, Credit: mapping.Accounts.Sales,
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			m := mapping
+			if tt.mapping != nil {
+				m = tt.mapping(m)
+			}
+			if err := m.Check(tt.format, period); err != nil {
+				t.Fatalf("Check() returned error: %v", err)
+			}
+			var b bytes.Buffer
+			if err := Write(&b, tt.format, m, period, tt.entries); err != nil {
+				t.Fatalf("Write() returned error: %v", err)
+			}
+			assertGolden(t, filepath.Join("testdata", tt.format+".golden"), b.Bytes())
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	complete := DefaultMapping()
+	complete.DATEV.ConsultantNumber = 1001
+	complete.DATEV.ClientNumber = 20002
+	july := complete
+	july.DATEV.FiscalYearStartMonth = 7
+
+	tests := []struct {
+		name    string
+		format  string
+		mapping Mapping
+		period  Period
+		wantErr string
+	}{
+		{name: "other formats need no settings", format: FormatXero, mapping: DefaultMapping(), period: Period{From: day(2025, 12, 1), To: day(2026, 1, 31)}},
+		{name: "missing DATEV numbers", format: FormatDATEV, mapping: DefaultMapping(), period: Period{From: day(2026, 10, 1), To: day(2026, 10, 31)}, wantErr: "consultant_number"},
+		{name: "calendar year", format: FormatDATEV, mapping: complete, period: Period{From: day(2026, 1, 1), To: day(2026, 12, 31)}},
+		{name: "across the new year", format: FormatDATEV, mapping: complete, period: Period{From: day(2025, 12, 1), To: day(2026, 1, 31)}, wantErr: "export until 2025-12-31 and from 2026-01-01"},
+		{name: "fiscal year from July", format: FormatDATEV, mapping: july, period: Period{From: day(2025, 12, 1), To: day(2026, 1, 31)}},
+		{name: "across the fiscal year from July", format: FormatDATEV, mapping: july, period: Period{From: day(2026, 6, 1), To: day(2026, 7, 1)}, wantErr: "export until 2026-06-30 and from 2026-07-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.mapping.Check(tt.format, tt.period)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("Check() returned error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("Check() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFiscalYearStart(t *testing.T) {
+	tests := []struct {
+		day        time.Time
+		startMonth int
+		want       time.Time
+	}{
+		{day: day(2026, 10, 15), startMonth: 1, want: day(2026, 1, 1)},
+		{day: day(2026, 10, 15), startMonth: 10, want: day(2026, 10, 1)},
+		{day: day(2026, 9, 30), startMonth: 10, want: day(2025, 10, 1)},
+	}
+	for _, tt := range tests {
+		if got := fiscalYearStart(tt.day, tt.startMonth); !got.Equal(tt.want) {
+			t.Errorf("fiscalYearStart(%s, %d) = %s, want %s", tt.day.Format(time.DateOnly), tt.startMonth, got.Format(time.DateOnly), tt.want.Format(time.DateOnly))
+		}
+	}
+}
+
+// testEntries covers every kind of entry, two partial refunds of the same
+// sale, a payout collecting deductions from the bank account, text outside of
+// ASCII and a reference longer than DATEV allows.
+func testEntries(m Mapping) []Entry {
+	accounts := m.Accounts
+	return []Entry{
+		{
+			Date: day(2026, 10, 2), Kind: KindSale, Reference: "TEENSK4W2K", Description: "Kaffee & Brötchen",
+			Amount: decimal.RequireFromString("12.50"), Currency: "EUR",
+			Debit: accounts.Clearing, Credit: accounts.Sales, TaxCode: m.TaxCodes.Sales,
+		},
+		{
+			Date: day(2026, 10, 3), Kind: KindRefund, Reference: "6b1f0c2e-3d4a-4e8b-9c7d-1a2b3c4d5e6f", Description: "SumUp refund TEENSK4W2K",
+			Amount: decimal.RequireFromString("2.50"), Currency: "EUR",
+			Debit: accounts.Refunds, Credit: accounts.Clearing, TaxCode: m.TaxCodes.Refunds,
+		},
+		{
+			Date: day(2026, 10, 3), Kind: KindRefund, Reference: "9e8d7c6b-5a4f-4e3d-8c2b-1a0f9e8d7c6b", Description: "SumUp refund TEENSK4W2K",
+			Amount: decimal.RequireFromString("1.00"), Currency: "EUR",
+			Debit: accounts.Refunds, Credit: accounts.Clearing, TaxCode: m.TaxCodes.Refunds,
+		},
+		{
+			Date: day(2026, 10, 4), Kind: KindChargeback, Reference: "0f8c3b1e-6f3a-4c53-9d1e-5b2f3e7a9c10", Description: `Disputed "order"`,
+			Amount: decimal.RequireFromString("1234.56"), Currency: "EUR",
+			Debit: accounts.Chargebacks, Credit: accounts.Clearing, TaxCode: m.TaxCodes.Chargebacks,
+		},
+		{
+			Date: day(2026, 10, 5), Kind: KindFee, Reference: "101", Description: "SumUp fees payout 101",
+			Amount: decimal.RequireFromString("0.43"), Currency: "EUR",
+			Debit: accounts.Fees, Credit: accounts.Clearing, TaxCode: m.TaxCodes.Fees,
+		},
+		{
+			Date: day(2026, 10, 5), Kind: KindPayout, Reference: "101", Description: "SumUp payout 101",
+			Amount: decimal.RequireFromString("9.57"), Currency: "EUR",
+			Debit: accounts.Bank, Credit: accounts.Clearing,
+		},
+		{
+			Date: day(2026, 10, 6), Kind: KindPayout, Reference: "102", Description: "SumUp payout 102",
+			Amount: decimal.RequireFromString("1224.99"), Currency: "EUR",
+			Debit: accounts.Clearing, Credit: accounts.Bank,
+		},
+	}
+}
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+// assertGolden compares the output with the golden file, or rewrites the
+// file when the tests run with -update.
+func assertGolden(t *testing.T, path string, got []byte) {
+	t.Helper()
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file, run the tests with -update to create it: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s, run the tests with -update after checking the change\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
@@ -0,0 +1,119 @@
+package accounting
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// DATEV limits the length of the document and posting text fields.
+const (
+	datevReferenceLength = 36
+	datevTextLength      = 60
+)
+
+// datevColumns are the leading columns of the DATEV Buchungsstapel format.
+// The remaining columns of the format are optional and left out.
+var datevColumns = []string{
+	"Umsatz (ohne Soll/Haben-Kz)",
+	"Soll/Haben-Kennzeichen",
+	"WKZ Umsatz",
+	"Kurs",
+	"Basis-Umsatz",
+	"WKZ Basis-Umsatz",
+	"Konto",
+	"Gegenkonto (ohne BU-Schlüssel)",
+	"BU-Schlüssel",
+	"Belegdatum",
+	"Belegfeld 1",
+	"Belegfeld 2",
+	"Skonto",
+	"Buchungstext",
+}
+
+// writeDATEV writes an EXTF Buchungsstapel: a header describing the batch,
+// the column names and one row per entry, debiting Konto and crediting
+// Gegenkonto. DATEV reads the file as Windows-1252 with CRLF line endings.
+func writeDATEV(w io.Writer, mapping Mapping, period Period, entries []Entry) error {
+	fiscalYear := fiscalYearStart(period.From, mapping.DATEV.FiscalYearStartMonth)
+
+	currency := "EUR"
+	if len(entries) > 0 {
+		currency = entries[0].Currency
+	}
+
+	header := []string{
+		`"EXTF"`, "700", "21", `"Buchungsstapel"`, "13",
+		now().Format("20060102150405") + "000",
+		"", `"SU"`, `""`, `""`,
+		strconv.Itoa(mapping.DATEV.ConsultantNumber),
+		strconv.Itoa(mapping.DATEV.ClientNumber),
+		fiscalYear.Format("20060102"),
+		strconv.Itoa(mapping.DATEV.AccountLength),
+		period.From.Format("20060102"),
+		period.To.Format("20060102"),
+		datevText("SumUp "+period.From.Format("01/2006"), 30),
+		`""`, "1", "0", "0",
+		datevText(currency, 3),
+		"", `""`, "", "", `""`, "", "", `""`, `""`,
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.Join(header, ";") + "\r\n")
+	columns := make([]string, len(datevColumns))
+	for i, column := range datevColumns {
+		columns[i] = datevText(column, 0)
+	}
+	b.WriteString(strings.Join(columns, ";") + "\r\n")
+
+	for _, entry := range entries {
+		row := []string{
+			strings.Replace(entry.Amount.StringFixed(2), ".", ",", 1),
+			`"S"`,
+			datevText(entry.Currency, 3),
+			"",
+			"",
+			`""`,
+			entry.Debit,
+			entry.Credit,
+			datevText(entry.TaxCode, 4),
+			entry.Date.Format("0201"),
+			datevText(entry.Reference, datevReferenceLength),
+			`""`,
+			"",
+			datevText(entry.Description, datevTextLength),
+		}
+		b.WriteString(strings.Join(row, ";") + "\r\n")
+	}
+
+	encoded, err := encoding.ReplaceUnsupported(charmap.Windows1252.NewEncoder()).String(b.String())
+	if err != nil {
+		return fmt.Errorf("encode DATEV export: %w", err)
+	}
+	_, err = io.WriteString(w, encoded)
+	return err
+}
+
+// datevText quotes a text field, cut to limit characters unless limit is
+// zero. Quotes are doubled as in CSV.
+func datevText(value string, limit int) string {
+	runes := []rune(value)
+	if limit > 0 && len(runes) > limit {
+		runes = runes[:limit]
+	}
+	return `"` + strings.ReplaceAll(string(runes), `"`, `""`) + `"`
+}
+
+// fiscalYearStart returns the start of the fiscal year that contains day.
+func fiscalYearStart(day time.Time, startMonth int) time.Time {
+	year := day.Year()
+	if int(day.Month()) < startMonth {
+		year--
+	}
+	return time.Date(year, time.Month(startMonth), 1, 0, 0, 0, 0, day.Location())
+}
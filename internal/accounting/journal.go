@@ -0,0 +1,101 @@
+package accounting
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// journalLine is one side of an entry in the Xero and QuickBooks exports,
+// which list the debit and the credit line of a journal separately.
+type journalLine struct {
+	account string
+	debit   bool
+	taxCode string
+}
+
+func (m Mapping) journalLines(entry Entry) []journalLine {
+	lines := []journalLine{
+		{account: entry.Debit, debit: true, taxCode: m.TaxCodes.None},
+		{account: entry.Credit, taxCode: m.TaxCodes.None},
+	}
+	for i := range lines {
+		if m.isIncomeOrExpense(lines[i].account) && entry.TaxCode != "" {
+			lines[i].taxCode = entry.TaxCode
+		}
+	}
+	return lines
+}
+
+// writeXero writes a manual journal import for Xero. Lines with the same
+// narration form one journal; debits are positive and credits negative.
+func writeXero(w io.Writer, mapping Mapping, entries []Entry) error {
+	layout := mapping.dateLayout("DD/MM/YYYY")
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{"*Narration", "*Date", "Description", "*AccountCode", "*TaxRate", "*Amount", "TrackingName1", "TrackingOption1", "TrackingName2", "TrackingOption2"})
+	for _, entry := range entries {
+		narration := fmt.Sprintf("SumUp %s %s", entry.Kind, entry.Reference)
+		for _, line := range mapping.journalLines(entry) {
+			amount := entry.Amount
+			if !line.debit {
+				amount = amount.Neg()
+			}
+			_ = writer.Write([]string{
+				narration,
+				entry.Date.Format(layout),
+				entry.Description,
+				line.account,
+				line.taxCode,
+				amount.StringFixed(2),
+				"", "", "", "",
+			})
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// quickBooksJournalNumberLength is the length QuickBooks allows for journal
+// numbers.
+const quickBooksJournalNumberLength = 21
+
+// journalNumberPrefixes keep the fee and the transfer of a payout, which share
+// the payout ID as reference, in separate journal entries.
+var journalNumberPrefixes = map[string]string{
+	KindSale:       "S",
+	KindRefund:     "R",
+	KindChargeback: "C",
+	KindFee:        "F",
+	KindPayout:     "P",
+}
+
+// writeQuickBooks writes a journal entry import for QuickBooks Online with
+// separate debit and credit columns. Lines with the same journal number form
+// one journal entry. The numbers are derived from the references, so they do
+// not clash with the entries of earlier exports.
+func writeQuickBooks(w io.Writer, mapping Mapping, entries []Entry) error {
+	layout := mapping.dateLayout("MM/DD/YYYY")
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{"Journal No", "Journal Date", "Currency", "Account", "Debits", "Credits", "Description", "Tax Code"})
+	for _, entry := range entries {
+		number := truncateRunes(journalNumberPrefixes[entry.Kind]+"-"+entry.Reference, quickBooksJournalNumberLength)
+		for _, line := range mapping.journalLines(entry) {
+			debits, credits := entry.Amount.StringFixed(2), ""
+			if !line.debit {
+				debits, credits = "", entry.Amount.StringFixed(2)
+			}
+			_ = writer.Write([]string{
+				number,
+				entry.Date.Format(layout),
+				entry.Currency,
+				line.account,
+				debits,
+				credits,
+				entry.Description,
+				line.taxCode,
+			})
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
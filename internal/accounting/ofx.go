@@ -0,0 +1,88 @@
+package accounting
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// ofxTransactionTypes maps entry kinds to OFX transaction types.
+var ofxTransactionTypes = map[string]string{
+	KindSale:       "CREDIT",
+	KindRefund:     "DEBIT",
+	KindChargeback: "DEBIT",
+	KindFee:        "FEE",
+	KindPayout:     "XFER",
+}
+
+// writeOFX writes the entries as an OFX 2.2 bank statement of the clearing
+// account, so tools without a journal import can read SumUp like a bank
+// account: sales add to it, refunds, chargebacks, fees and payouts take from
+// it. OFX statements hold one currency each, so there is one per currency.
+// The ledger balance is the net change over the period, since the balance
+// held by SumUp is not known.
+func writeOFX(w io.Writer, mapping Mapping, period Period, entries []Entry) error {
+	var currencies []string
+	byCurrency := map[string][]Entry{}
+	for _, entry := range entries {
+		if _, ok := byCurrency[entry.Currency]; !ok {
+			currencies = append(currencies, entry.Currency)
+		}
+		byCurrency[entry.Currency] = append(byCurrency[entry.Currency], entry)
+	}
+
+	created := now().Format("20060102150405")
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="no"?>` + "\n")
+	b.WriteString(`<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>` + "\n")
+	b.WriteString("<OFX>\n<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>")
+	fmt.Fprintf(&b, "<DTSERVER>%s</DTSERVER><LANGUAGE>ENG</LANGUAGE></SONRS></SIGNONMSGSRSV1>\n<BANKMSGSRSV1>\n", created)
+
+	for i, currency := range currencies {
+		fmt.Fprintf(&b, "<STMTTRNRS><TRNUID>%d</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>\n", i+1)
+		fmt.Fprintf(&b, "<STMTRS><CURDEF>%s</CURDEF>\n", ofxText(currency))
+		fmt.Fprintf(&b, "<BANKACCTFROM><BANKID>SUMUP</BANKID><ACCTID>%s</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>\n", ofxText(mapping.Accounts.Clearing))
+		fmt.Fprintf(&b, "<BANKTRANLIST><DTSTART>%s</DTSTART><DTEND>%s</DTEND>\n", period.From.Format("20060102"), period.To.Format("20060102"))
+
+		balance := decimal.Zero
+		for _, entry := range byCurrency[currency] {
+			amount := entry.Amount
+			if entry.Credit == mapping.Accounts.Clearing {
+				amount = amount.Neg()
+			}
+			balance = balance.Add(amount)
+			fmt.Fprintf(&b, "<STMTTRN><TRNTYPE>%s</TRNTYPE><DTPOSTED>%s</DTPOSTED><TRNAMT>%s</TRNAMT><FITID>%s</FITID><NAME>%s</NAME><MEMO>%s</MEMO></STMTTRN>\n",
+				ofxTransactionTypes[entry.Kind],
+				entry.Date.Format("20060102"),
+				amount.StringFixed(2),
+				ofxText(entry.Kind+"-"+entry.Reference),
+				ofxText(truncateRunes(entry.Description, 32)),
+				ofxText(entry.Reference),
+			)
+		}
+		b.WriteString("</BANKTRANLIST>\n")
+		fmt.Fprintf(&b, "<LEDGERBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></LEDGERBAL>\n", balance.StringFixed(2), period.To.Format("20060102"))
+		b.WriteString("</STMTRS></STMTTRNRS>\n")
+	}
+
+	b.WriteString("</BANKMSGSRSV1>\n</OFX>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func ofxText(value string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
+
+func truncateRunes(value string, length int) string {
+	runes := []rune(value)
+	if len(runes) <= length {
+		return value
+	}
+	return string(runes[:length])
+}
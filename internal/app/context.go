@@ -16,16 +16,25 @@ import (
 // ContextKey is used to store the initialized context in the CLI metadata map.
 const ContextKey = "app-context"
 
+// ArgsKey is used to store the arguments of the command line as typed, before
+// aliases are expanded, in the CLI metadata map.
+const ArgsKey = "args"
+
 // Context carries shared dependencies for commands.
 type Context struct {
 	Client          *sumup.Client
+	RawClient       *sumupclient.Client
+	APIKey          string
+	BaseURL         string
 	JSONOutput      bool
 	ExactTimestamps bool
+	DryRun          bool
+	AssumeYes       bool
 	Locale          string
 }
 
 // NewContext constructs the CLI context with an initialized SumUp API client.
-func NewContext(apiKey, baseURL string, jsonOutput bool, exactTimestamps bool) (*Context, error) {
+func NewContext(apiKey, baseURL string, jsonOutput bool, exactTimestamps bool, dryRun bool, assumeYes bool) (*Context, error) {
 	var opts []sumupclient.ClientOption
 	if apiKey != "" {
 		opts = append(opts, sumupclient.WithAPIKey(apiKey))
@@ -37,12 +46,25 @@ func NewContext(apiKey, baseURL string, jsonOutput bool, exactTimestamps bool) (
 	client := sumup.NewClient(opts...)
 	return &Context{
 		Client:          client,
+		RawClient:       sumupclient.New(opts...),
+		APIKey:          apiKey,
+		BaseURL:         baseURL,
 		JSONOutput:      jsonOutput,
 		ExactTimestamps: exactTimestamps,
+		DryRun:          dryRun,
+		AssumeYes:       assumeYes,
 		Locale:          detectLocale(),
 	}, nil
 }
 
+// IsProduction reports whether the context talks to the production SumUp API.
+func (c *Context) IsProduction() bool {
+	if c.BaseURL == "" {
+		return true
+	}
+	return strings.TrimRight(c.BaseURL, "/") == strings.TrimRight(sumupclient.APIUrl, "/")
+}
+
 func detectLocale() string {
 	envs := []string{"LC_ALL", "LC_TIME", "LANG"}
 	for _, key := range envs {
@@ -0,0 +1,158 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sumup/sumup-cli/internal/config"
+)
+
+// Outcomes recorded for audited commands.
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// sensitiveFlags lists flags whose values must never end up in the audit log.
+var sensitiveFlags = map[string]bool{
+	"api-key":       true,
+	"password":      true,
+	"affiliate-key": true,
+}
+
+// Entry is a single record of a state-changing command.
+type Entry struct {
+	Timestamp    time.Time `json:"timestamp"`
+	User         string    `json:"user"`
+	BaseURL      string    `json:"base_url,omitempty"`
+	MerchantCode string    `json:"merchant_code,omitempty"`
+	Command      string    `json:"command"`
+	Arguments    []string  `json:"arguments"`
+	ResourceID   string    `json:"resource_id,omitempty"`
+	Outcome      string    `json:"outcome"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// logPath returns the path to the audit log file.
+func logPath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "audit.log"), nil
+}
+
+// Append writes the entry as a JSON line at the end of the audit log.
+func Append(entry Entry) error {
+	path, err := logPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create config directory: %w", err)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshal audit entry: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("open audit log: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write audit log: %w", err)
+	}
+	return nil
+}
+
+// List returns the audit entries recorded at or after since, oldest first.
+func List(since time.Time) ([]Entry, error) {
+	path, err := logPath()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Entry{}, nil
+		}
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+	defer file.Close()
+
+	entries := []Entry{}
+	scanner := bufio.NewScanner(file)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("parse audit log line %d: %w", line, err)
+		}
+		if entry.Timestamp.Before(since) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read audit log: %w", err)
+	}
+
+	return entries, nil
+}
+
+// CurrentUser returns the name of the operating system user running the CLI.
+func CurrentUser() string {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return current.Username
+	}
+	for _, key := range []string{"USER", "USERNAME"} {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+	}
+	return "unknown"
+}
+
+// SanitizeArgs returns a copy of the command line arguments with the values of
+// sensitive flags redacted.
+func SanitizeArgs(args []string) []string {
+	sanitized := make([]string, 0, len(args))
+	redactNext := false
+	for _, arg := range args {
+		if redactNext {
+			sanitized = append(sanitized, "***")
+			redactNext = false
+			continue
+		}
+
+		name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || !sensitiveFlags[name] {
+			sanitized = append(sanitized, arg)
+			continue
+		}
+
+		if hasValue {
+			sanitized = append(sanitized, arg[:strings.Index(arg, "=")+1]+"***")
+		} else {
+			sanitized = append(sanitized, arg)
+			redactNext = true
+		}
+	}
+	return sanitized
+}
@@ -0,0 +1,88 @@
+// Package cache stores small JSON documents in the user cache directory, per
+// API host and key, for data that only speeds up interactive features.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sumup/sumup-cli/internal/app"
+)
+
+// Cache is a directory of cached documents. A Cache without a directory,
+// when the user cache directory is unknown, stores nothing.
+type Cache struct {
+	dir string
+}
+
+type entry struct {
+	CreatedAt time.Time       `json:"created_at"`
+	Value     json.RawMessage `json:"value"`
+}
+
+// New returns the cache called name for the API host and key of appCtx, so
+// that switching accounts or environments does not show stale data.
+func New(appCtx *app.Context, name string) *Cache {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return &Cache{}
+	}
+	apiKey := appCtx.APIKey
+	if apiKey == "" {
+		apiKey = os.Getenv("SUMUP_API_KEY")
+	}
+	return &Cache{dir: filepath.Join(dir, "sumup", name, hash(appCtx.BaseURL+"\x00"+apiKey))}
+}
+
+// Load decodes the document stored under key into value and returns when it
+// was stored. Callers decide whether it is still recent enough.
+func (c *Cache) Load(key string, value any) (time.Time, bool) {
+	if c.dir == "" {
+		return time.Time{}, false
+	}
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return time.Time{}, false
+	}
+	var stored entry
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return time.Time{}, false
+	}
+	if err := json.Unmarshal(stored.Value, value); err != nil {
+		return time.Time{}, false
+	}
+	return stored.CreatedAt, true
+}
+
+// Save stores value under key. Failures are ignored, the cache only saves a
+// request next time.
+func (c *Cache) Save(key string, value any) {
+	if c.dir == "" {
+		return
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(entry{CreatedAt: time.Now(), Value: encoded})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return
+	}
+	_ = os.WriteFile(c.path(key), data, 0600)
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, hash(key)+".json")
+}
+
+func hash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,234 @@
+package alias
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/config"
+	"github.com/sumup/sumup-cli/internal/display"
+	"github.com/sumup/sumup-cli/internal/display/message"
+)
+
+type defaultValue struct {
+	Command string `json:"command"`
+	Flag    string `json:"flag"`
+	Value   string `json:"value"`
+	Source  string `json:"source"`
+}
+
+func NewCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "alias",
+		Usage: "Manage command aliases and default flag values.",
+		Description: `Aliases are stored in the "aliases" section of the configuration file.
+Default flag values for a command are read from its "defaults" section, for example:
+
+  "defaults": {
+    "readers checkout": {"currency": ["EUR"], "tip-rate": ["0.1", "0.15"]}
+  }
+
+Defaults apply unless the flag is set on the command line or through its environment variable.`,
+		Commands: []*cli.Command{
+			{
+				Name:      "set",
+				Usage:     "Define an alias for a command line.",
+				ArgsUsage: "<name> <command>",
+				Description: `Examples:
+  sumup alias set pay 'readers checkout --currency EUR --tip-rate 0.1'`,
+				Action: setAlias,
+			},
+			{
+				Name:      "unset",
+				Usage:     "Remove an alias.",
+				ArgsUsage: "<name>",
+				Action:    unsetAlias,
+			},
+			{
+				Name:   "list",
+				Usage:  "List aliases and default flag values with their source.",
+				Action: listAliases,
+			},
+		},
+	}
+}
+
+func setAlias(_ context.Context, cmd *cli.Command) error {
+	args := cmd.Args()
+	if args.Len() < 2 {
+		return fmt.Errorf("alias name and command arguments are required")
+	}
+	name := args.First()
+	expansion := strings.Join(args.Tail(), " ")
+
+	if strings.HasPrefix(name, "-") || strings.ContainsAny(name, " \t") {
+		return fmt.Errorf("invalid alias name %q", name)
+	}
+	if cmd.Root().Command(name) != nil {
+		return fmt.Errorf("%q is a built-in command and cannot be used as an alias", name)
+	}
+	words, err := SplitArgs(expansion)
+	if err != nil {
+		return err
+	}
+	if len(words) == 0 {
+		return fmt.Errorf("alias command cannot be empty")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if _, ok := cfg.Aliases[words[0]]; ok {
+		return fmt.Errorf("alias %q cannot expand to another alias", name)
+	}
+	if cfg.Aliases == nil {
+		cfg.Aliases = map[string]string{}
+	}
+	cfg.Aliases[name] = expansion
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("save alias: %w", err)
+	}
+
+	message.Success("Alias %s set to: %s", name, expansion)
+	return nil
+}
+
+func unsetAlias(_ context.Context, cmd *cli.Command) error {
+	args := cmd.Args()
+	if args.Len() != 1 {
+		return fmt.Errorf("alias name argument is required")
+	}
+	name := args.First()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if _, ok := cfg.Aliases[name]; !ok {
+		return fmt.Errorf("alias %q is not defined", name)
+	}
+	delete(cfg.Aliases, name)
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("save aliases: %w", err)
+	}
+
+	message.Success("Alias %s removed", name)
+	return nil
+}
+
+func listAliases(_ context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	names := sortedKeys(cfg.Aliases)
+	defaults := []defaultValue{}
+	for _, name := range names {
+		defaults = append(defaults, aliasDefaults(cmd.Root(), name, cfg.Aliases[name])...)
+	}
+	for _, command := range sortedKeys(cfg.Defaults) {
+		for _, flag := range sortedKeys(cfg.Defaults[command]) {
+			for _, value := range cfg.Defaults[command][flag] {
+				defaults = append(defaults, defaultValue{
+					Command: command,
+					Flag:    flag,
+					Value:   value,
+					Source:  "defaults",
+				})
+			}
+		}
+	}
+
+	if appCtx.JSONOutput {
+		aliases := cfg.Aliases
+		if aliases == nil {
+			aliases = map[string]string{}
+		}
+		return display.PrintJSON(map[string]any{
+			"aliases":  aliases,
+			"defaults": defaults,
+		})
+	}
+
+	aliasRows := make([][]string, 0, len(names))
+	for _, name := range names {
+		aliasRows = append(aliasRows, []string{name, cfg.Aliases[name]})
+	}
+	display.RenderTable("Aliases", []string{"Alias", "Command"}, aliasRows)
+
+	defaultRows := make([][]string, 0, len(defaults))
+	for _, value := range defaults {
+		defaultRows = append(defaultRows, []string{value.Command, "--" + value.Flag, value.Value, value.Source})
+	}
+	display.RenderTable("Defaults", []string{"Command", "Flag", "Value", "Source"}, defaultRows)
+
+	if path, err := config.Path(); err == nil {
+		message.Notify("Configuration file: %s", path)
+	}
+	return nil
+}
+
+// aliasDefaults returns the flag values an alias sets for its command. Like
+// the configured defaults, they apply unless the flag is set explicitly.
+func aliasDefaults(root *cli.Command, name, expansion string) []defaultValue {
+	words, err := SplitArgs(expansion)
+	if err != nil {
+		return nil
+	}
+
+	cmd := root
+	flags := slices.Clone(root.Flags)
+	var path []string
+	values := []defaultValue{}
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if !strings.HasPrefix(word, "-") {
+			if sub := cmd.Command(word); sub != nil && len(values) == 0 {
+				cmd = sub
+				flags = append(flags, sub.Flags...)
+				path = append(path, sub.Name)
+			}
+			continue
+		}
+
+		flagName, value, hasValue := strings.Cut(strings.TrimLeft(word, "-"), "=")
+		if !hasValue {
+			value = "true"
+			if takesValue(lookupFlag(flags, flagName)) && i+1 < len(words) {
+				i++
+				value = words[i]
+			}
+		}
+		values = append(values, defaultValue{
+			Flag:   flagName,
+			Value:  value,
+			Source: fmt.Sprintf("alias %s", name),
+		})
+	}
+
+	for i := range values {
+		values[i].Command = strings.Join(path, " ")
+	}
+	return values
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,231 @@
+package alias
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/sumup/sumup-cli/internal/config"
+)
+
+// Expand rewrites the command line before it is parsed. A leading alias is
+// replaced with its expansion and the configured defaults of the resolved
+// command are inserted for every flag that is not set explicitly, either on
+// the command line or through its environment variable. The flags of an
+// alias are defaults as well: a flag set explicitly replaces them.
+func Expand(root *cli.Command, args []string) ([]string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Aliases) == 0 && len(cfg.Defaults) == 0 {
+		return args, nil
+	}
+
+	out := slices.Clone(args)
+	flags := slices.Clone(root.Flags)
+	idx := nextPositional(out, 1, flags)
+	if idx == -1 {
+		return out, nil
+	}
+
+	if root.Command(out[idx]) == nil {
+		if expansion, ok := cfg.Aliases[out[idx]]; ok {
+			words, err := SplitArgs(expansion)
+			if err != nil {
+				return nil, fmt.Errorf("expand alias %q: %w", out[idx], err)
+			}
+			words = withoutExplicit(root, words, slices.Concat(out[1:idx], out[idx+1:]))
+			out = slices.Concat(out[:idx], words, out[idx+1:])
+		}
+	}
+
+	cmd := root
+	var path []string
+	end := -1
+	for idx != -1 {
+		sub := cmd.Command(out[idx])
+		if sub == nil {
+			break
+		}
+		cmd = sub
+		path = append(path, sub.Name)
+		flags = append(flags, sub.Flags...)
+		end = idx + 1
+		idx = nextPositional(out, end, flags)
+	}
+	if end == -1 {
+		return out, nil
+	}
+
+	defaults := cfg.Defaults[strings.Join(path, " ")]
+	if len(defaults) == 0 {
+		return out, nil
+	}
+
+	names := make([]string, 0, len(defaults))
+	for name := range defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var inserted []string
+	for _, name := range names {
+		flag := lookupFlag(flags, name)
+		if flag == nil {
+			return nil, fmt.Errorf("unknown flag %q in defaults for %q", name, strings.Join(path, " "))
+		}
+		if isExplicit(out[1:], flag) {
+			continue
+		}
+		for _, value := range defaults[name] {
+			inserted = append(inserted, fmt.Sprintf("--%s=%s", name, value))
+		}
+	}
+
+	return slices.Concat(out[:end], inserted, out[end:]), nil
+}
+
+// withoutExplicit drops the flags of an alias expansion, along with their
+// values, that are set explicitly in args.
+func withoutExplicit(root *cli.Command, words, args []string) []string {
+	cmd := root
+	flags := slices.Clone(root.Flags)
+	kept := make([]string, 0, len(words))
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if word == "--" {
+			return append(kept, words[i:]...)
+		}
+		if !strings.HasPrefix(word, "-") || word == "-" {
+			if sub := cmd.Command(word); sub != nil {
+				cmd = sub
+				flags = append(flags, sub.Flags...)
+			}
+			kept = append(kept, word)
+			continue
+		}
+
+		name, _, hasValue := strings.Cut(strings.TrimLeft(word, "-"), "=")
+		flag := lookupFlag(flags, name)
+		end := i + 1
+		if !hasValue && takesValue(flag) && end < len(words) {
+			end++
+		}
+		if flag == nil || !isExplicit(args, flag) {
+			kept = append(kept, words[i:end]...)
+		}
+		i = end - 1
+	}
+	return kept
+}
+
+// nextPositional returns the index of the first positional argument at or
+// after start, skipping flags and their values, or -1 if there is none.
+func nextPositional(args []string, start int, flags []cli.Flag) int {
+	for i := start; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return -1
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			return i
+		}
+		name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !hasValue && takesValue(lookupFlag(flags, name)) {
+			i++
+		}
+	}
+	return -1
+}
+
+func lookupFlag(flags []cli.Flag, name string) cli.Flag {
+	for _, flag := range flags {
+		if slices.Contains(flag.Names(), name) {
+			return flag
+		}
+	}
+	return nil
+}
+
+func takesValue(flag cli.Flag) bool {
+	docFlag, ok := flag.(cli.DocGenerationFlag)
+	return ok && docFlag.TakesValue()
+}
+
+// isExplicit reports whether the flag is set on the command line or through
+// one of its environment variables.
+func isExplicit(args []string, flag cli.Flag) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if slices.Contains(flag.Names(), name) {
+			return true
+		}
+	}
+
+	if docFlag, ok := flag.(cli.DocGenerationFlag); ok {
+		for _, key := range docFlag.GetEnvVars() {
+			if value, ok := os.LookupEnv(key); ok && value != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SplitArgs splits a command line into words, honouring single and
+// double quotes and backslash escapes like a POSIX shell.
+func SplitArgs(value string) ([]string, error) {
+	var (
+		words   []string
+		current strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range value {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("unterminated quote or escape in %q", value)
+	}
+	if inWord {
+		words = append(words, current.String())
+	}
+	return words, nil
+}
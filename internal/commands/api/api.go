@@ -0,0 +1,210 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	sumupclient "github.com/sumup/sumup-go/client"
+
+	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/commands/util"
+	"github.com/sumup/sumup-cli/internal/display"
+	"github.com/sumup/sumup-cli/internal/display/message"
+)
+
+const merchantCodePlaceholder = "{merchant_code}"
+
+var supportedMethods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+func NewCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "api",
+		Usage:     "Make an authenticated request to any SumUp API endpoint.",
+		ArgsUsage: "<method> <path>",
+		Description: `The {merchant_code} placeholder in the path is replaced with the --merchant-code flag or the merchant context.
+
+Examples:
+  sumup api GET /v0.1/me
+  sumup api GET /v2.1/merchants/{merchant_code}/transactions/history --query limit=5 --query statuses=SUCCESSFUL
+  sumup api POST /v0.1/merchants/{merchant_code}/readers --data @reader.json`,
+		Action:                    callAPI,
+		DisableSliceFlagSeparator: true,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "merchant-code",
+				Usage:   "Merchant code substituted for {merchant_code} in the path. Falls back to context.",
+				Sources: cli.EnvVars("SUMUP_MERCHANT_CODE"),
+			},
+			&cli.StringFlag{
+				Name:  "data",
+				Usage: "JSON request body. Use @file to read it from a file or @- to read it from stdin.",
+			},
+			&cli.StringSliceFlag{
+				Name:  "query",
+				Usage: "Query parameter in key=value form (repeat flag for multiple parameters).",
+			},
+		},
+	}
+}
+
+func callAPI(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	args := cmd.Args()
+	if args.Len() != 2 {
+		return fmt.Errorf("expected <method> <path> arguments, got %d", args.Len())
+	}
+	method, err := parseMethod(args.Get(0))
+	if err != nil {
+		return err
+	}
+
+	path, rawQuery, _ := strings.Cut(args.Get(1), "?")
+	var merchantCode string
+	if strings.Contains(path, merchantCodePlaceholder) {
+		merchantCode, err = app.GetMerchantCode(cmd, "merchant-code")
+		if err != nil {
+			return err
+		}
+		path = strings.ReplaceAll(path, merchantCodePlaceholder, url.PathEscape(merchantCode))
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return fmt.Errorf("invalid query in path: %w", err)
+	}
+	for _, pair := range cmd.StringSlice("query") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("invalid --query %q, expected key=value", pair)
+		}
+		query.Add(key, value)
+	}
+
+	var body json.RawMessage
+	if cmd.IsSet("data") {
+		body, err = readData(cmd.String("data"))
+		if err != nil {
+			return err
+		}
+	}
+
+	if appCtx.DryRun && method != http.MethodGet {
+		target := path
+		if len(query) > 0 {
+			target += "?" + query.Encode()
+		}
+		if body == nil {
+			return util.DryRun(appCtx, method, target, nil)
+		}
+		return util.DryRun(appCtx, method, target, body)
+	}
+
+	opts := []sumupclient.RequestOption{}
+	if len(query) > 0 {
+		opts = append(opts, sumupclient.WithQueryValues(query))
+	}
+	if body != nil {
+		opts = append(opts, sumupclient.WithJSONBody(body))
+	}
+
+	resp, err := appCtx.RawClient.Call(ctx, method, path, opts...)
+	if err != nil {
+		err = fmt.Errorf("call %s %s: %w", method, path, err)
+		if method != http.MethodGet {
+			util.RecordAudit(cmd, merchantCode, path, err)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	payload, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+
+	var callErr error
+	if resp.StatusCode >= http.StatusBadRequest {
+		callErr = fmt.Errorf("unexpected response %d: %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	if method != http.MethodGet {
+		util.RecordAudit(cmd, merchantCode, path, callErr)
+	}
+
+	if !appCtx.JSONOutput {
+		if callErr != nil {
+			message.Error("%s", resp.Status)
+		} else {
+			message.Success("%s", resp.Status)
+		}
+	}
+	if err := printPayload(payload); err != nil {
+		return err
+	}
+	return callErr
+}
+
+func parseMethod(value string) (string, error) {
+	method := strings.ToUpper(value)
+	for _, supported := range supportedMethods {
+		if method == supported {
+			return method, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported method %q. Supported values: %s", value, strings.Join(supportedMethods, ", "))
+}
+
+// readData returns the request body from a literal value, a file (@path) or
+// stdin (@-) and makes sure it is valid JSON.
+func readData(value string) (json.RawMessage, error) {
+	var data []byte
+	switch {
+	case value == "@-":
+		content, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("read request body from stdin: %w", err)
+		}
+		data = content
+	case strings.HasPrefix(value, "@"):
+		content, err := os.ReadFile(strings.TrimPrefix(value, "@"))
+		if err != nil {
+			return nil, fmt.Errorf("read request body: %w", err)
+		}
+		data = content
+	default:
+		data = []byte(value)
+	}
+
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("request body is not valid JSON")
+	}
+	return json.RawMessage(data), nil
+}
+
+func printPayload(payload []byte) error {
+	if len(strings.TrimSpace(string(payload))) == 0 {
+		return nil
+	}
+	if !json.Valid(payload) {
+		fmt.Println(string(payload))
+		return nil
+	}
+	return display.PrintJSON(json.RawMessage(payload))
+}
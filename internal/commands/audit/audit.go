@@ -0,0 +1,131 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/audit"
+	"github.com/sumup/sumup-cli/internal/commands/util"
+	"github.com/sumup/sumup-cli/internal/display"
+)
+
+func NewCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "audit",
+		Usage: "Inspect the local audit log of state-changing commands.",
+		Commands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "List audit log entries.",
+				Action: listEntries,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "since",
+						Usage: "Only show entries newer than a duration (for example 12h, 7d, 2w) or a date (YYYY-MM-DD or RFC3339).",
+					},
+					&cli.StringFlag{
+						Name:  "user",
+						Usage: "Only show entries recorded by this operating system user.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func listEntries(_ context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	var since time.Time
+	if value := cmd.String("since"); value != "" {
+		since, err = parseSince(value, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	entries, err := audit.List(since)
+	if err != nil {
+		return fmt.Errorf("read audit log: %w", err)
+	}
+	if user := cmd.String("user"); user != "" {
+		filtered := make([]audit.Entry, 0, len(entries))
+		for _, entry := range entries {
+			if entry.User == user {
+				filtered = append(filtered, entry)
+			}
+		}
+		entries = filtered
+	}
+
+	if appCtx.JSONOutput {
+		return display.PrintJSON(entries)
+	}
+
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		timestamp := entry.Timestamp
+		rows = append(rows, []string{
+			util.TimeOrDash(appCtx, &timestamp),
+			entry.User,
+			valueOrDash(entry.MerchantCode),
+			entry.Command,
+			valueOrDash(entry.ResourceID),
+			outcomeLabel(entry),
+		})
+	}
+
+	display.RenderTable("Audit Log", []string{"Time", "User", "Merchant", "Command", "Resource", "Outcome"}, rows)
+	return nil
+}
+
+// parseSince accepts a duration relative to now, with additional support for
+// days (d) and weeks (w), or an absolute date.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	if parsed, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		return parsed, nil
+	}
+
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if number, ok := strings.CutSuffix(value, suffix); ok {
+			count, err := strconv.Atoi(number)
+			if err != nil || count < 0 {
+				return time.Time{}, fmt.Errorf("invalid value for --since: %q", value)
+			}
+			return now.Add(-time.Duration(count) * unit), nil
+		}
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return time.Time{}, fmt.Errorf("invalid value for --since: %q", value)
+	}
+	return now.Add(-duration), nil
+}
+
+func outcomeLabel(entry audit.Entry) string {
+	if entry.Outcome == audit.OutcomeFailure && entry.Error != "" {
+		return fmt.Sprintf("%s: %s", entry.Outcome, entry.Error)
+	}
+	return entry.Outcome
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
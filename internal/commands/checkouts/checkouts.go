@@ -3,6 +3,7 @@ package checkouts
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -70,6 +71,10 @@ func NewCommand() *cli.Command {
 						Name:  "return-url",
 						Usage: "URL SumUp should redirect to after payment.",
 					},
+					&cli.BoolFlag{
+						Name:  "listen",
+						Usage: "Use the URL of the running 'sumup listen' receiver as the return URL.",
+					},
 					&cli.StringFlag{
 						Name:  "redirect-url",
 						Usage: "Optional URL for redirecting the payer after 3DS flows.",
@@ -149,9 +154,14 @@ func createCheckout(ctx context.Context, cmd *cli.Command) error {
 		return err
 	}
 
+	amount := cmd.Float64("amount")
+	if amount <= 0 {
+		return fmt.Errorf("amount must be greater than zero")
+	}
+
 	body := checkouts.CreateCheckoutBody{
 		CheckoutReference: cmd.String("reference"),
-		Amount:            float32(cmd.Float64("amount")),
+		Amount:            float32(amount),
 		Currency:          parsedCurrency,
 		MerchantCode:      merchantCode,
 	}
@@ -159,8 +169,12 @@ func createCheckout(ctx context.Context, cmd *cli.Command) error {
 	if value := cmd.String("description"); value != "" {
 		body.Description = &value
 	}
-	if value := cmd.String("return-url"); value != "" {
-		body.ReturnUrl = &value
+	returnURL, err := util.ReturnURL(cmd)
+	if err != nil {
+		return err
+	}
+	if returnURL != "" {
+		body.ReturnUrl = &returnURL
 	}
 	if value := cmd.String("redirect-url"); value != "" {
 		body.RedirectUrl = &value
@@ -173,10 +187,16 @@ func createCheckout(ctx context.Context, cmd *cli.Command) error {
 		body.Purpose = &purpose
 	}
 
+	if appCtx.DryRun {
+		return util.DryRun(appCtx, http.MethodPost, "/v0.1/checkouts", body)
+	}
+
 	checkout, err := appCtx.Client.Checkouts.Create(ctx, body)
 	if err != nil {
+		util.RecordAudit(cmd, merchantCode, "", err)
 		return fmt.Errorf("create checkout: %w", err)
 	}
+	util.RecordAudit(cmd, merchantCode, util.StringOrDefault(checkout.ID, ""), nil)
 
 	if appCtx.JSONOutput {
 		return display.PrintJSON(checkout)
@@ -208,7 +228,35 @@ func deactivateCheckout(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
+
+	if appCtx.DryRun {
+		return util.DryRun(appCtx, http.MethodDelete, "/v0.1/checkouts/"+checkoutID, nil)
+	}
+
+	current, err := appCtx.Client.Checkouts.Get(ctx, checkoutID)
+	if err != nil {
+		return fmt.Errorf("retrieve checkout: %w", err)
+	}
+	status := "-"
+	if current.Status != nil {
+		status = string(*current.Status)
+	}
+	err = util.Confirm(appCtx, util.Confirmation{
+		Action: "deactivate checkout",
+		Name:   util.StringOrDefault(current.CheckoutReference, ""),
+		Details: []attribute.KeyValue{
+			attribute.ID(checkoutID),
+			attribute.Attribute("Reference", attribute.Styled(util.StringOrDefault(current.CheckoutReference, "N/A"))),
+			attribute.Attribute("Amount", attribute.Styled(currency.FormatPointers(current.Amount, current.Currency))),
+			attribute.Attribute("Status", attribute.Styled(status)),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
 	checkout, err := appCtx.Client.Checkouts.Deactivate(ctx, checkoutID)
+	util.RecordAudit(cmd, util.StringOrDefault(current.MerchantCode, ""), checkoutID, err)
 	if err != nil {
 		return fmt.Errorf("deactivate checkout: %w", err)
 	}
@@ -3,32 +3,67 @@ package commands
 import (
 	"github.com/urfave/cli/v3"
 
+	"github.com/sumup/sumup-cli/internal/commands/alias"
+	"github.com/sumup/sumup-cli/internal/commands/api"
+	"github.com/sumup/sumup-cli/internal/commands/audit"
 	"github.com/sumup/sumup-cli/internal/commands/checkouts"
 	"github.com/sumup/sumup-cli/internal/commands/context"
 	"github.com/sumup/sumup-cli/internal/commands/customers"
+	"github.com/sumup/sumup-cli/internal/commands/dashboard"
+	"github.com/sumup/sumup-cli/internal/commands/export"
+	"github.com/sumup/sumup-cli/internal/commands/listen"
 	"github.com/sumup/sumup-cli/internal/commands/members"
 	"github.com/sumup/sumup-cli/internal/commands/memberships"
 	"github.com/sumup/sumup-cli/internal/commands/merchants"
 	"github.com/sumup/sumup-cli/internal/commands/payouts"
+	"github.com/sumup/sumup-cli/internal/commands/plugin"
 	"github.com/sumup/sumup-cli/internal/commands/readers"
 	"github.com/sumup/sumup-cli/internal/commands/receipts"
 	"github.com/sumup/sumup-cli/internal/commands/roles"
+	"github.com/sumup/sumup-cli/internal/commands/shell"
 	"github.com/sumup/sumup-cli/internal/commands/transactions"
+	"github.com/sumup/sumup-cli/internal/completion"
 )
 
 // All returns the list of resource commands exposed by the CLI.
 func All() []*cli.Command {
-	return []*cli.Command{
+	all := []*cli.Command{
+		alias.NewCommand(),
+		api.NewCommand(),
+		audit.NewCommand(),
 		checkouts.NewCommand(),
 		context.NewCommand(),
 		customers.NewCommand(),
+		dashboard.NewCommand(),
+		export.NewCommand(),
+		listen.NewCommand(),
 		members.NewCommand(),
 		memberships.NewCommand(),
 		merchants.NewCommand(),
 		payouts.NewCommand(),
+		plugin.NewCommand(),
 		readers.NewCommand(),
 		receipts.NewCommand(),
 		roles.NewCommand(),
+		shell.NewCommand(All),
 		transactions.NewCommand(),
 	}
+	for _, cmd := range all {
+		setDefaultCompletion(cmd)
+	}
+	return all
+}
+
+// setDefaultCompletion enables completion of shared flag values, such as
+// merchant codes and currencies, on commands without a custom completer.
+func setDefaultCompletion(cmd *cli.Command) {
+	if len(cmd.Commands) == 0 {
+		if cmd.ShellComplete == nil {
+			cmd.ShellComplete = completion.Complete(nil, nil)
+		}
+		return
+	}
+	for _, sub := range cmd.Commands {
+		setDefaultCompletion(sub)
+	}
 }
@@ -2,7 +2,10 @@ package context
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -10,18 +13,26 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/urfave/cli/v3"
+	"golang.org/x/term"
 
 	"github.com/sumup/sumup-go"
 	"github.com/sumup/sumup-go/memberships"
 	"github.com/sumup/sumup-go/shared"
 
 	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/completion"
 	"github.com/sumup/sumup-cli/internal/config"
 	"github.com/sumup/sumup-cli/internal/display/message"
+	"github.com/sumup/sumup-cli/internal/membership"
 )
 
 const (
 	debounceDelay = 500 * time.Millisecond
+	// pickerPageSize is the number of memberships requested per page.
+	pickerPageSize = 50
+	// prefetchItems is how close to the end of the loaded memberships the
+	// cursor may get before the next page is requested.
+	prefetchItems = 5
 )
 
 func NewCommand() *cli.Command {
@@ -30,15 +41,35 @@ func NewCommand() *cli.Command {
 		Usage: "Manage merchant context for commands.",
 		Commands: []*cli.Command{
 			{
-				Name:   "set",
-				Usage:  "Set the current merchant context.",
-				Action: setContext,
+				Name:          "set",
+				Usage:         "Set the current merchant context.",
+				ArgsUsage:     "[merchant-code]",
+				Description:   "Without a merchant code, pick the merchant interactively. A merchant code is\nvalidated against your memberships, including merchants of your organizations.",
+				Action:        setContext,
+				ShellComplete: completion.Complete(completion.MerchantCodes, nil),
+			},
+			{
+				Name:          "use",
+				Usage:         "Switch the merchant context, or back to the previous one with '-'.",
+				ArgsUsage:     "<merchant-code|->",
+				Action:        useContext,
+				ShellComplete: completion.Complete(completion.MerchantCodes, nil),
 			},
 			{
 				Name:   "get",
 				Usage:  "Get the current merchant context.",
 				Action: getContext,
 			},
+			{
+				Name:   "show",
+				Usage:  "Show the merchant name, organization path and your roles for the current context.",
+				Action: showContext,
+			},
+			{
+				Name:   "recent",
+				Usage:  "List recently used merchant contexts.",
+				Action: recentContexts,
+			},
 			{
 				Name:   "unset",
 				Usage:  "Unset the current merchant context.",
@@ -49,33 +80,56 @@ func NewCommand() *cli.Command {
 }
 
 type searchResultMsg struct {
+	// Level, query and offset the memberships were requested for
+	parentID    string
+	query       string
+	offset      int
 	memberships []memberships.Membership
+	total       int
 	err         error
 }
 
+// cacheMsg carries the cached memberships of a level
+type cacheMsg struct {
+	parentID string
+	entry    *membership.CacheEntry
+}
+
 // searchDebounceMsg is sent after the debounce delay to trigger the actual search
 type searchDebounceMsg struct{}
 
+// refreshMsg triggers the periodic background refresh of the current level
+type refreshMsg struct{}
+
 type navigationLevel struct {
 	memberships []memberships.Membership
 	parentID    string
 	parentType  memberships.ResourceType
 	parentName  string
+	// Number of memberships available, further pages are loaded on scroll
+	total int
+	// Search query the memberships were loaded for
+	query string
 }
 
 type model struct {
 	ctx    context.Context
 	client *sumup.Client
+	cache  *membership.Cache
 	// Error state
 	err error
 	// Stack of navigation levels for back navigation
 	navigationStack []navigationLevel
 	// Current level being displayed
 	currentLevel navigationLevel
-	// Currently displayed items (filtered or all)
+	// Currently displayed items, favourites first
 	displayed []memberships.Membership
+	// Starred merchants
+	favorites []config.FavoriteMerchant
 	// Current cursor position in the list
 	cursor int
+	// Number of items shown at once, fitted to the terminal height
+	maxVisible int
 	// Search input field
 	searchInput textinput.Model
 	// Whether search mode is active
@@ -84,30 +138,44 @@ type model struct {
 	selected *memberships.Membership
 	// Whether a search is in progress
 	loading bool
+	// Whether the next page is being loaded
+	loadingMore bool
 	// Last executed search query to avoid duplicate requests
 	lastSearchQuery string
 	// Whether a search is pending (debouncing)
 	searchPending bool
+	// Feedback shown below the help line
+	status string
 }
 
 func (m model) Init() tea.Cmd {
-	return textinput.Blink
+	return tea.Batch(textinput.Blink, m.loadLevel(""), refreshTick())
 }
 
 // clearSearch exits search mode and restores the current level's items
-func (m *model) clearSearch() {
+func (m *model) clearSearch() tea.Cmd {
 	m.searching = false
+	m.searchPending = false
 	m.searchInput.SetValue("")
-	m.displayed = m.currentLevel.memberships
-	m.lastSearchQuery = ""
 	m.cursor = 0
+	if m.lastSearchQuery == "" {
+		return nil
+	}
+	m.lastSearchQuery = ""
+	m.loading = true
+	return m.loadLevel(m.currentLevel.parentID)
 }
 
 // pushLevel saves current level to stack and sets a new current level
 func (m *model) pushLevel(newLevel navigationLevel) {
+	m.currentLevel.query = m.lastSearchQuery
 	m.navigationStack = append(m.navigationStack, m.currentLevel)
 	m.currentLevel = newLevel
-	m.displayed = newLevel.memberships
+	m.searching = false
+	m.searchPending = false
+	m.searchInput.SetValue("")
+	m.lastSearchQuery = ""
+	m.refreshDisplayed()
 	m.cursor = 0
 }
 
@@ -119,22 +187,25 @@ func (m *model) popLevel() {
 	previousLevel := m.navigationStack[len(m.navigationStack)-1]
 	m.navigationStack = m.navigationStack[:len(m.navigationStack)-1]
 	m.currentLevel = previousLevel
-	m.displayed = previousLevel.memberships
+	m.lastSearchQuery = previousLevel.query
+	m.searchInput.SetValue(previousLevel.query)
+	m.loading = false
+	m.loadingMore = false
+	m.refreshDisplayed()
 	m.cursor = 0
 }
 
 // drillDownIntoOrg navigates into an organization to view its child merchants
 func (m *model) drillDownIntoOrg(orgID, orgName string) tea.Cmd {
-	parentType := memberships.ResourceType("organization")
 	newLevel := navigationLevel{
 		memberships: []memberships.Membership{},
 		parentID:    orgID,
-		parentType:  parentType,
+		parentType:  membership.TypeOrganization,
 		parentName:  orgName,
 	}
 	m.pushLevel(newLevel)
 	m.loading = true
-	return m.searchMemberships("", orgID, parentType)
+	return m.loadLevel(orgID)
 }
 
 // debounce returns a command that waits for the debounce delay before sending a searchDebounceMsg
@@ -144,12 +215,53 @@ func debounce() tea.Cmd {
 	})
 }
 
+// refreshTick schedules the next background refresh
+func refreshTick() tea.Cmd {
+	return tea.Tick(membership.CacheTTL, func(t time.Time) tea.Msg {
+		return refreshMsg{}
+	})
+}
+
+// loadLevel shows the cached memberships of a level, or fetches the first
+// page if nothing is cached. Stale cache entries are refreshed once shown.
+func (m model) loadLevel(parentID string) tea.Cmd {
+	return func() tea.Msg {
+		if entry, ok := m.cache.Load(parentID); ok {
+			return cacheMsg{parentID: parentID, entry: entry}
+		}
+		return m.searchMemberships("", parentID, 0, pickerPageSize)()
+	}
+}
+
+// refresh reloads the memberships of the current level in the background,
+// as many as are loaded already.
+func (m model) refresh() tea.Cmd {
+	limit := max(pickerPageSize, len(m.currentLevel.memberships))
+	return m.searchMemberships("", m.currentLevel.parentID, 0, limit)
+}
+
+// loadMore requests the next page when the cursor gets close to the end of
+// the loaded memberships.
+func (m *model) loadMore() tea.Cmd {
+	level := m.currentLevel
+	if m.loading || m.loadingMore || len(level.memberships) >= level.total {
+		return nil
+	}
+	if m.cursor < len(m.displayed)-prefetchItems {
+		return nil
+	}
+	m.loadingMore = true
+	return m.searchMemberships(m.lastSearchQuery, level.parentID, len(level.memberships), pickerPageSize)
+}
+
 // searchMemberships performs an API call to search for memberships by name
-func (m model) searchMemberships(query string, parentID string, parentType memberships.ResourceType) tea.Cmd {
+func (m model) searchMemberships(query string, parentID string, offset, limit int) tea.Cmd {
 	return func() tea.Msg {
 		status := shared.MembershipStatusAccepted
 		params := memberships.ListMembershipsParams{
 			Status: &status,
+			Limit:  &limit,
+			Offset: &offset,
 		}
 
 		if query != "" {
@@ -157,19 +269,98 @@ func (m model) searchMemberships(query string, parentID string, parentType membe
 		}
 
 		if parentID != "" {
+			parentType := membership.TypeOrganization
 			params.ResourceParentId = &parentID
-		}
-
-		if parentType != "" {
 			params.ResourceParentType = &parentType
 		}
 
 		response, err := m.client.Memberships.List(m.ctx, params)
 		if err != nil {
-			return searchResultMsg{err: err}
+			return searchResultMsg{parentID: parentID, query: query, offset: offset, err: err}
+		}
+
+		return searchResultMsg{
+			parentID:    parentID,
+			query:       query,
+			offset:      offset,
+			memberships: response.Items,
+			total:       response.TotalCount,
+		}
+	}
+}
+
+func (m *model) isFavorite(merchantCode string) bool {
+	return slices.ContainsFunc(m.favorites, func(favorite config.FavoriteMerchant) bool {
+		return strings.EqualFold(favorite.MerchantCode, merchantCode)
+	})
+}
+
+// refreshDisplayed lists the favourites first. At the top level, favourites
+// belonging to organizations are listed as well, so they can be selected
+// without drilling down.
+func (m *model) refreshDisplayed() {
+	var favorites, others []memberships.Membership
+	listed := map[string]bool{}
+	for _, item := range m.currentLevel.memberships {
+		code := membership.MerchantCode(item)
+		if item.Resource.Type == membership.TypeMerchant && m.isFavorite(code) {
+			favorites = append(favorites, item)
+			listed[strings.ToUpper(code)] = true
+			continue
+		}
+		others = append(others, item)
+	}
+	if m.currentLevel.parentID == "" && m.lastSearchQuery == "" {
+		for _, favorite := range m.favorites {
+			if listed[strings.ToUpper(favorite.MerchantCode)] {
+				continue
+			}
+			favorites = append(favorites, memberships.Membership{
+				Resource: memberships.MembershipResource{
+					ID:   favorite.MerchantCode,
+					Name: favorite.Name,
+					Type: membership.TypeMerchant,
+				},
+			})
 		}
+	}
+	m.displayed = append(favorites, others...)
+	if m.cursor >= len(m.displayed) {
+		m.cursor = max(0, len(m.displayed)-1)
+	}
+}
 
-		return searchResultMsg{memberships: response.Items}
+// toggleFavorite stars or unstars the merchant under the cursor
+func (m *model) toggleFavorite() {
+	if m.cursor >= len(m.displayed) {
+		return
+	}
+	item := m.displayed[m.cursor]
+	if item.Resource.Type != membership.TypeMerchant {
+		m.status = "Only merchants can be starred."
+		return
+	}
+	code := membership.MerchantCode(item)
+	starred, err := config.ToggleFavoriteMerchant(code, item.Resource.Name)
+	if err != nil {
+		m.status = fmt.Sprintf("Failed to save favourite: %v", err)
+		return
+	}
+	if cfg, err := config.Load(); err == nil {
+		m.favorites = cfg.FavoriteMerchants
+	}
+	if starred {
+		m.status = fmt.Sprintf("Starred %s.", item.Resource.Name)
+	} else {
+		m.status = fmt.Sprintf("Removed the star from %s.", item.Resource.Name)
+	}
+
+	m.refreshDisplayed()
+	for i, displayed := range m.displayed {
+		if strings.EqualFold(membership.MerchantCode(displayed), code) && displayed.Resource.Type == membership.TypeMerchant {
+			m.cursor = i
+			break
+		}
 	}
 }
 
@@ -177,18 +368,56 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		// Leave room for the title, search, paging and help lines.
+		m.maxVisible = max(3, msg.Height-10)
+		return m, nil
+
+	case cacheMsg:
+		if msg.parentID != m.currentLevel.parentID || m.lastSearchQuery != "" {
+			return m, nil
+		}
+		m.loading = false
+		m.currentLevel.memberships = msg.entry.Items
+		m.currentLevel.total = msg.entry.Total
+		m.refreshDisplayed()
+		if msg.entry.Stale() {
+			return m, m.refresh()
+		}
+		return m, m.loadMore()
+
 	case searchResultMsg:
+		if msg.parentID != m.currentLevel.parentID || msg.query != m.lastSearchQuery {
+			return m, nil
+		}
 		m.loading = false
+		m.loadingMore = false
 		if msg.err != nil {
+			// Keep showing cached memberships if a refresh fails.
+			if len(m.currentLevel.memberships) > 0 {
+				m.status = fmt.Sprintf("Failed to load memberships: %v", msg.err)
+				return m, nil
+			}
 			m.err = msg.err
 			return m, nil
 		}
-		m.currentLevel.memberships = msg.memberships
-		m.displayed = msg.memberships
-		if m.cursor >= len(m.displayed) {
-			m.cursor = max(0, len(m.displayed)-1)
+		if msg.offset == 0 {
+			m.currentLevel.memberships = msg.memberships
+		} else if msg.offset == len(m.currentLevel.memberships) {
+			m.currentLevel.memberships = append(m.currentLevel.memberships, msg.memberships...)
 		}
-		return m, nil
+		m.currentLevel.total = msg.total
+		if msg.query == "" {
+			m.cache.Save(msg.parentID, m.currentLevel.memberships, msg.total)
+		}
+		m.refreshDisplayed()
+		return m, m.loadMore()
+
+	case refreshMsg:
+		if m.loading || m.lastSearchQuery != "" {
+			return m, refreshTick()
+		}
+		return m, tea.Batch(m.refresh(), refreshTick())
 
 	case searchDebounceMsg:
 		if !m.searchPending {
@@ -201,15 +430,24 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.lastSearchQuery = query
 		m.loading = true
-		return m, m.searchMemberships(query, m.currentLevel.parentID, m.currentLevel.parentType)
+		m.loadingMore = false
+		if query == "" {
+			return m, m.loadLevel(m.currentLevel.parentID)
+		}
+		return m, m.searchMemberships(query, m.currentLevel.parentID, 0, pickerPageSize)
 
 	case tea.KeyMsg:
+		m.status = ""
 		switch msg.String() {
-		case "ctrl+c", "q":
+		case "ctrl+c":
 			return m, tea.Quit
+		case "q":
+			if !m.searching {
+				return m, tea.Quit
+			}
 		case "esc":
 			if m.searching {
-				m.clearSearch()
+				return m, m.clearSearch()
 			} else if len(m.navigationStack) > 0 {
 				m.popLevel()
 			}
@@ -219,6 +457,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.searching = true
 				return m, m.searchInput.Focus()
 			}
+		case "s", "*":
+			if !m.searching {
+				m.toggleFavorite()
+				return m, nil
+			}
 		case "enter":
 			if m.searching {
 				m.searching = false
@@ -227,7 +470,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			if len(m.displayed) > 0 && m.cursor < len(m.displayed) {
 				selectedMembership := m.displayed[m.cursor]
-				if selectedMembership.Resource.Type == "organization" {
+				if selectedMembership.Resource.Type == membership.TypeOrganization {
 					return m, m.drillDownIntoOrg(selectedMembership.Resource.ID, selectedMembership.Resource.Name)
 				} else {
 					// Select merchant
@@ -246,7 +489,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.cursor < len(m.displayed)-1 {
 					m.cursor++
 				}
+				return m, m.loadMore()
 			}
+		case "pgup":
+			m.cursor = max(0, m.cursor-m.maxVisible)
+			return m, nil
+		case "pgdown":
+			m.cursor = max(0, min(len(m.displayed)-1, m.cursor+m.maxVisible))
+			return m, m.loadMore()
 		}
 	}
 
@@ -293,7 +543,7 @@ func (m model) View() string {
 	}
 
 	items := m.displayed
-	maxVisible := 10
+	maxVisible := m.maxVisible
 	start := 0
 	end := len(items)
 
@@ -310,29 +560,31 @@ func (m model) View() string {
 
 	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("2")).Bold(true)
 	orgStyle := lipgloss.NewStyle().Faint(true)
+	starStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
 
 	for i := start; i < end; i++ {
-		membership := items[i]
+		item := items[i]
 		cursor := " "
 		if i == m.cursor {
 			cursor = ">"
 		}
 
 		var line string
-		if membership.Resource.Type == "organization" {
-			line = fmt.Sprintf("%s Organization: %s (%s)", cursor, membership.Resource.Name, membership.Resource.ID)
+		if item.Resource.Type == membership.TypeOrganization {
+			line = fmt.Sprintf("%s   Organization: %s (%s)", cursor, item.Resource.Name, item.Resource.ID)
 			if i != m.cursor {
 				line = orgStyle.Render(line)
 			}
 		} else {
-			// Get merchant code
-			code := "-"
-			if membership.Resource.Attributes != nil {
-				if codeVal, ok := membership.Resource.Attributes["merchant_code"].(string); ok {
-					code = codeVal
+			code := membership.MerchantCode(item)
+			star := " "
+			if m.isFavorite(code) {
+				star = "★"
+				if i != m.cursor {
+					star = starStyle.Render(star)
 				}
 			}
-			line = fmt.Sprintf("%s %s (%s)", cursor, membership.Resource.Name, code)
+			line = fmt.Sprintf("%s %s %s (%s)", cursor, star, item.Resource.Name, code)
 		}
 
 		if i == m.cursor {
@@ -349,22 +601,30 @@ func (m model) View() string {
 			s.WriteString("No items found.")
 		}
 		s.WriteString("\n")
-	} else if len(items) > maxVisible {
-		s.WriteString(fmt.Sprintf("\n(Showing %d-%d of %d)", start+1, end, len(items)))
+	} else if len(items) > maxVisible || m.loadingMore {
+		total := max(len(items), m.currentLevel.total)
+		s.WriteString(fmt.Sprintf("\n(Showing %d-%d of %d)", start+1, end, total))
+		if m.loadingMore {
+			s.WriteString(" loading more...")
+		}
 	}
 
 	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 	s.WriteString("\n\n")
 	if m.searching {
-		s.WriteString(helpStyle.Render("esc: exit search | enter: confirm | ctrl+c/q: quit"))
+		s.WriteString(helpStyle.Render("esc: exit search | enter: confirm | ctrl+c: quit"))
 	} else {
-		help := "↑/↓ or j/k: navigate | /: search | enter: select"
+		help := "↑/↓ or j/k: navigate | /: search | s: star | enter: select"
 		if len(m.navigationStack) > 0 {
 			help += " | esc: back"
 		}
 		help += " | ctrl+c/q: quit"
 		s.WriteString(helpStyle.Render(help))
 	}
+	if m.status != "" {
+		s.WriteString("\n")
+		s.WriteString(m.status)
+	}
 
 	return s.String()
 }
@@ -375,21 +635,19 @@ func setContext(ctx context.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	message.Notify("Fetching your memberships...")
-
-	status := shared.MembershipStatusAccepted
-	params := memberships.ListMembershipsParams{
-		Status: &status,
+	if cmd.Args().Len() > 1 {
+		return fmt.Errorf("expected at most one merchant code, got %d arguments", cmd.Args().Len())
 	}
-
-	response, err := appCtx.Client.Memberships.List(ctx, params)
-	if err != nil {
-		return fmt.Errorf("list memberships: %w", err)
+	if merchantCode := cmd.Args().First(); merchantCode != "" {
+		return switchContext(ctx, appCtx, merchantCode)
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return errors.New("merchant code is required when not running in a terminal: sumup context set <merchant-code>")
 	}
 
-	if len(response.Items) == 0 {
-		message.Warn("No memberships found.")
-		return nil
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
 	}
 
 	searchInput := textinput.New()
@@ -399,12 +657,12 @@ func setContext(ctx context.Context, cmd *cli.Command) error {
 	p := tea.NewProgram(model{
 		client:          appCtx.Client,
 		ctx:             ctx,
+		cache:           membership.NewCache(appCtx),
 		navigationStack: []navigationLevel{},
-		currentLevel: navigationLevel{
-			memberships: response.Items,
-		},
-		displayed:   response.Items,
-		searchInput: searchInput,
+		favorites:       cfg.FavoriteMerchants,
+		maxVisible:      10,
+		loading:         true,
+		searchInput:     searchInput,
 	})
 	result, err := p.Run()
 	if err != nil {
@@ -412,23 +670,26 @@ func setContext(ctx context.Context, cmd *cli.Command) error {
 	}
 
 	finalModel := result.(model)
+	if finalModel.err != nil {
+		return fmt.Errorf("list memberships: %w", finalModel.err)
+	}
 	if finalModel.selected == nil {
 		message.Warn("No merchant selected.")
 		return nil
 	}
 
-	if finalModel.selected.Resource.Type == "organization" {
+	if finalModel.selected.Resource.Type == membership.TypeOrganization {
 		message.Warn("Please select a merchant, not an organization.")
 		return nil
 	}
 
 	// Get merchant code
-	merchantCode := finalModel.selected.Resource.ID
+	merchantCode := membership.MerchantCode(*finalModel.selected)
 	if merchantCode == "" {
 		return fmt.Errorf("merchant code not found in membership attributes")
 	}
 
-	if err := config.SetCurrentMerchantCode(merchantCode); err != nil {
+	if err := config.UseMerchant(merchantCode, finalModel.selected.Resource.Name); err != nil {
 		return fmt.Errorf("save merchant context: %w", err)
 	}
 
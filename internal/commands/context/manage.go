@@ -0,0 +1,186 @@
+package context
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/sumup/sumup-go/merchants"
+
+	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/commands/util"
+	"github.com/sumup/sumup-cli/internal/config"
+	"github.com/sumup/sumup-cli/internal/display"
+	"github.com/sumup/sumup-cli/internal/display/attribute"
+	"github.com/sumup/sumup-cli/internal/display/message"
+	"github.com/sumup/sumup-cli/internal/membership"
+)
+
+// contextDetails is the JSON output of context show.
+type contextDetails struct {
+	MerchantCode  string   `json:"merchant_code"`
+	Name          string   `json:"name,omitempty"`
+	Organizations []string `json:"organizations"`
+	Roles         []string `json:"roles"`
+}
+
+// switchContext validates the merchant code against the memberships of the
+// user and makes it the current context.
+func switchContext(ctx context.Context, appCtx *app.Context, merchantCode string) error {
+	path, err := membership.FindMerchant(ctx, appCtx.Client, merchantCode)
+	if err != nil {
+		if errors.Is(err, membership.ErrNotFound) {
+			return fmt.Errorf("merchant %s not found in your memberships", merchantCode)
+		}
+		return fmt.Errorf("list memberships: %w", err)
+	}
+
+	merchantCode = membership.MerchantCode(path.Membership)
+	name := path.Membership.Resource.Name
+	if err := config.UseMerchant(merchantCode, name); err != nil {
+		return fmt.Errorf("save merchant context: %w", err)
+	}
+
+	message.Success("Merchant context set to: %s (%s)", name, merchantCode)
+	return nil
+}
+
+func useContext(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Args().Len() != 1 {
+		return errors.New("usage: sumup context use <merchant-code|->")
+	}
+	merchantCode := cmd.Args().First()
+	if merchantCode != "-" {
+		return switchContext(ctx, appCtx, merchantCode)
+	}
+
+	// The previous merchant was validated when it was set.
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if cfg.PreviousMerchantCode == "" {
+		return errors.New("no previous merchant context")
+	}
+	previous := cfg.PreviousMerchantCode
+	name := cfg.RecentMerchantName(previous)
+	if err := config.UseMerchant(previous, name); err != nil {
+		return fmt.Errorf("save merchant context: %w", err)
+	}
+
+	if name != "" {
+		message.Success("Merchant context set to: %s (%s)", name, previous)
+	} else {
+		message.Success("Merchant context set to: %s", previous)
+	}
+	return nil
+}
+
+func showContext(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	merchantCode, err := config.GetCurrentMerchantCode()
+	if err != nil {
+		return fmt.Errorf("get merchant context: %w", err)
+	}
+	if merchantCode == "" {
+		message.Notify("No merchant context set.")
+		message.Notify("Use 'sumup context set' to set a merchant context.")
+		return nil
+	}
+
+	details := contextDetails{
+		MerchantCode:  merchantCode,
+		Organizations: []string{},
+		Roles:         []string{},
+	}
+	path, err := membership.FindMerchant(ctx, appCtx.Client, merchantCode)
+	switch {
+	case errors.Is(err, membership.ErrNotFound):
+		message.Warn("Merchant %s is not in your memberships anymore.", merchantCode)
+	case err != nil:
+		return fmt.Errorf("list memberships: %w", err)
+	default:
+		details.Name = path.Membership.Resource.Name
+		for _, org := range path.Organizations {
+			details.Organizations = append(details.Organizations, org.Name)
+		}
+		details.Roles = append(details.Roles, path.Membership.Roles...)
+	}
+
+	merchant, err := appCtx.Client.Merchants.Get(ctx, merchantCode, merchants.GetMerchantParams{})
+	if err != nil {
+		return fmt.Errorf("get merchant: %w", err)
+	}
+	if merchant.Company != nil && merchant.Company.Name != nil {
+		details.Name = *merchant.Company.Name
+	}
+
+	if appCtx.JSONOutput {
+		return display.PrintJSON(details)
+	}
+
+	organization := "-"
+	if path != nil && len(path.Organizations) > 0 {
+		organization = path.OrganizationPath()
+	}
+	roles := "-"
+	if len(details.Roles) > 0 {
+		roles = strings.Join(details.Roles, ", ")
+	}
+	display.DataList([]attribute.KeyValue{
+		attribute.Attribute("Merchant Code", attribute.Styled(details.MerchantCode)),
+		attribute.Attribute("Name", attribute.Styled(util.StringOrDefault(&details.Name, "-"))),
+		attribute.Attribute("Organization", attribute.Styled(organization)),
+		attribute.Attribute("Roles", attribute.Styled(roles)),
+	})
+	return nil
+}
+
+func recentContexts(_ context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	if appCtx.JSONOutput {
+		recent := cfg.RecentMerchants
+		if recent == nil {
+			recent = []config.RecentMerchant{}
+		}
+		return display.PrintJSON(recent)
+	}
+
+	rows := make([][]string, 0, len(cfg.RecentMerchants))
+	for _, recent := range cfg.RecentMerchants {
+		current := ""
+		if recent.MerchantCode == cfg.CurrentMerchantCode {
+			current = "*"
+		}
+		usedAt := recent.UsedAt
+		rows = append(rows, []string{
+			current,
+			recent.MerchantCode,
+			util.StringOrDefault(&recent.Name, "-"),
+			util.TimeOrDash(appCtx, &usedAt),
+		})
+	}
+	display.RenderTable("Recent merchants", []string{"Current", "Merchant Code", "Name", "Last Used"}, rows)
+	return nil
+}
@@ -0,0 +1,495 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/urfave/cli/v3"
+
+	"github.com/sumup/sumup-go/payouts"
+	"github.com/sumup/sumup-go/readers"
+	"github.com/sumup/sumup-go/transactions"
+
+	"github.com/sumup/sumup-cli/internal/app"
+	transactionscmd "github.com/sumup/sumup-cli/internal/commands/transactions"
+	"github.com/sumup/sumup-cli/internal/commands/util"
+	"github.com/sumup/sumup-cli/internal/currency"
+	"github.com/sumup/sumup-cli/internal/display"
+)
+
+func NewCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "dashboard",
+		Usage:  "Show a live full-screen dashboard for a merchant.",
+		Action: runDashboard,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "merchant-code",
+				Usage:   "Merchant code to show. Falls back to context.",
+				Sources: cli.EnvVars("SUMUP_MERCHANT_CODE"),
+			},
+			&cli.StringFlag{
+				Name:  "currency",
+				Usage: "Currency for reader checkouts. Defaults to the currency of the latest transaction.",
+			},
+		},
+	}
+}
+
+type pane int
+
+const (
+	paneTotals pane = iota
+	paneFeed
+	paneReaders
+	panePayouts
+)
+
+// mode is the interaction the dashboard is in.
+type mode int
+
+const (
+	modeBrowse mode = iota
+	modeDetails
+	modeRefund
+	modeCheckout
+	modeConfirm
+)
+
+// detailsMsg carries a transaction loaded for the details view.
+type detailsMsg struct {
+	transaction *transactions.TransactionFull
+	err         error
+}
+
+// actionMsg reports the outcome of a refund or a reader checkout.
+type actionMsg struct {
+	text string
+	err  error
+}
+
+// pendingAction is a refund or checkout waiting for confirmation.
+type pendingAction struct {
+	prompt string
+	run    tea.Cmd
+}
+
+type model struct {
+	ctx          context.Context
+	appCtx       *app.Context
+	cmd          *cli.Command
+	merchantCode string
+	currency     string
+	width        int
+	height       int
+	// Pane receiving the navigation keys, either the feed or the readers
+	focus pane
+	mode  mode
+
+	totals        *totals
+	totalsErr     error
+	totalsUpdated time.Time
+
+	feed        []transactions.TransactionHistory
+	feedErr     error
+	feedUpdated time.Time
+	feedCursor  int
+
+	readers        []readers.Reader
+	readersErr     error
+	readersUpdated time.Time
+	readerCursor   int
+
+	payouts        []payouts.FinancialPayout
+	payoutsErr     error
+	payoutsUpdated time.Time
+
+	// Generation of the refresh timer of each pane. Every result schedules
+	// the next refresh with a new generation, so that ticks scheduled
+	// before a manual refresh are dropped and each pane keeps one timer.
+	tickGeneration [panePayouts + 1]int
+
+	// Rendered details of the selected transaction
+	details string
+	// Amount input for refunds and checkouts
+	amountInput textinput.Model
+	pending     *pendingAction
+	// Result of the last action, shown in the footer
+	status    string
+	statusErr bool
+}
+
+func runDashboard(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		return err
+	}
+	if value := cmd.String("currency"); value != "" {
+		if _, err := currency.Parse(value); err != nil {
+			return err
+		}
+	}
+
+	amountInput := textinput.New()
+	amountInput.Prompt = ""
+	amountInput.CharLimit = 16
+
+	p := tea.NewProgram(model{
+		ctx:          ctx,
+		appCtx:       appCtx,
+		cmd:          cmd,
+		merchantCode: merchantCode,
+		currency:     strings.ToUpper(cmd.String("currency")),
+		focus:        paneFeed,
+		amountInput:  amountInput,
+	}, tea.WithAltScreen(), tea.WithContext(ctx))
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("run dashboard: %w", err)
+	}
+	return nil
+}
+
+func (m model) Init() tea.Cmd {
+	return tea.Batch(
+		m.fetch(paneTotals),
+		m.fetch(paneFeed),
+		m.fetch(paneReaders),
+		m.fetch(panePayouts),
+	)
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tickMsg:
+		if msg.generation != m.tickGeneration[msg.pane] {
+			return m, nil
+		}
+		return m, m.fetch(msg.pane)
+
+	case totalsMsg:
+		m.totals, m.totalsErr = msg.totals, msg.err
+		m.totalsUpdated = time.Now()
+		next := m.tick(paneTotals)
+		return m, next
+
+	case feedMsg:
+		m.feed, m.feedErr = msg.items, msg.err
+		m.feedUpdated = time.Now()
+		m.feedCursor = min(m.feedCursor, max(0, len(m.feed)-1))
+		next := m.tick(paneFeed)
+		return m, next
+
+	case readersMsg:
+		m.readers, m.readersErr = msg.items, msg.err
+		m.readersUpdated = time.Now()
+		m.readerCursor = min(m.readerCursor, max(0, len(m.readers)-1))
+		next := m.tick(paneReaders)
+		return m, next
+
+	case payoutsMsg:
+		m.payouts, m.payoutsErr = msg.items, msg.err
+		m.payoutsUpdated = time.Now()
+		next := m.tick(panePayouts)
+		return m, next
+
+	case detailsMsg:
+		if msg.err != nil {
+			m.mode = modeBrowse
+			m.setStatus(fmt.Sprintf("Failed to load transaction: %v", msg.err), true)
+			return m, nil
+		}
+		m.details = display.DataListString(transactionscmd.DetailAttributes(m.appCtx, msg.transaction))
+		return m, nil
+
+	case actionMsg:
+		if msg.err != nil {
+			m.setStatus(msg.err.Error(), true)
+		} else {
+			m.setStatus(msg.text, false)
+		}
+		// Show the effect of the action right away.
+		return m, tea.Batch(m.fetch(paneTotals), m.fetch(paneFeed), m.fetch(paneReaders))
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		switch m.mode {
+		case modeDetails:
+			return m.updateDetails(msg)
+		case modeRefund, modeCheckout:
+			return m.updateAmount(msg)
+		case modeConfirm:
+			return m.updateConfirm(msg)
+		default:
+			return m.updateBrowse(msg)
+		}
+	}
+
+	return m, nil
+}
+
+func (m model) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "tab", "shift+tab":
+		if m.focus == paneFeed {
+			m.focus = paneReaders
+		} else {
+			m.focus = paneFeed
+		}
+	case "up", "k":
+		if m.focus == paneFeed && m.feedCursor > 0 {
+			m.feedCursor--
+		}
+		if m.focus == paneReaders && m.readerCursor > 0 {
+			m.readerCursor--
+		}
+	case "down", "j":
+		if m.focus == paneFeed && m.feedCursor < len(m.feed)-1 {
+			m.feedCursor++
+		}
+		if m.focus == paneReaders && m.readerCursor < len(m.readers)-1 {
+			m.readerCursor++
+		}
+	case "R":
+		m.setStatus("Refreshing...", false)
+		return m, tea.Batch(
+			m.fetch(paneTotals),
+			m.fetch(paneFeed),
+			m.fetch(paneReaders),
+			m.fetch(panePayouts),
+		)
+	case "enter":
+		tx, ok := m.selectedTransaction()
+		if !ok {
+			return m, nil
+		}
+		m.mode = modeDetails
+		m.details = ""
+		return m, m.loadDetails(*tx.ID)
+	case "r":
+		tx, ok := m.selectedTransaction()
+		if !ok {
+			return m, nil
+		}
+		if tx.Status == nil || *tx.Status != transactions.TransactionHistoryStatusSuccessful {
+			m.setStatus("Only successful transactions can be refunded.", true)
+			return m, nil
+		}
+		m.mode = modeRefund
+		m.amountInput.SetValue("")
+		m.amountInput.Placeholder = "full amount"
+		return m, m.amountInput.Focus()
+	case "c":
+		if m.focus != paneReaders || len(m.readers) == 0 {
+			m.setStatus("Select a reader in the readers pane to start a checkout.", true)
+			return m, nil
+		}
+		if m.checkoutCurrency() == "" {
+			m.setStatus("Unknown checkout currency, restart with --currency.", true)
+			return m, nil
+		}
+		m.mode = modeCheckout
+		m.amountInput.SetValue("")
+		m.amountInput.Placeholder = "amount"
+		return m, m.amountInput.Focus()
+	}
+	return m, nil
+}
+
+func (m model) updateDetails(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "enter", "q":
+		m.mode = modeBrowse
+	case "r":
+		m.mode = modeBrowse
+		return m.updateBrowse(msg)
+	}
+	return m, nil
+}
+
+func (m model) updateAmount(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.mode = modeBrowse
+		m.amountInput.Blur()
+		return m, nil
+	case "enter":
+		m.amountInput.Blur()
+		var pending *pendingAction
+		var err error
+		if m.mode == modeRefund {
+			pending, err = m.refundAction(strings.TrimSpace(m.amountInput.Value()))
+		} else {
+			pending, err = m.checkoutAction(strings.TrimSpace(m.amountInput.Value()))
+		}
+		if err != nil {
+			m.mode = modeBrowse
+			m.setStatus(err.Error(), true)
+			return m, nil
+		}
+		m.pending = pending
+		m.mode = modeConfirm
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.amountInput, cmd = m.amountInput.Update(msg)
+	return m, cmd
+}
+
+func (m model) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		m.mode = modeBrowse
+		m.setStatus("Sending...", false)
+		run := m.pending.run
+		m.pending = nil
+		return m, run
+	case "n", "N", "esc":
+		m.mode = modeBrowse
+		m.pending = nil
+		m.setStatus("Cancelled.", false)
+	}
+	return m, nil
+}
+
+func (m *model) setStatus(text string, isErr bool) {
+	m.status = text
+	m.statusErr = isErr
+}
+
+func (m model) selectedTransaction() (transactions.TransactionHistory, bool) {
+	if m.focus != paneFeed || m.feedCursor >= len(m.feed) {
+		return transactions.TransactionHistory{}, false
+	}
+	tx := m.feed[m.feedCursor]
+	return tx, tx.ID != nil
+}
+
+func (m model) loadDetails(transactionID string) tea.Cmd {
+	return func() tea.Msg {
+		transaction, err := m.appCtx.Client.Transactions.Get(m.ctx, m.merchantCode, transactions.GetTransactionV21Params{
+			ID: &transactionID,
+		})
+		return detailsMsg{transaction: transaction, err: err}
+	}
+}
+
+// refundAction validates the refund amount of the selected transaction. An
+// empty amount refunds the transaction in full.
+func (m model) refundAction(value string) (*pendingAction, error) {
+	tx, ok := m.selectedTransaction()
+	if !ok {
+		return nil, fmt.Errorf("no transaction selected")
+	}
+
+	body := transactions.RefundTransactionBody{}
+	label := currency.FormatPointers(tx.Amount, tx.Currency)
+	if value != "" {
+		amount, err := strconv.ParseFloat(value, 64)
+		if err != nil || amount <= 0 {
+			return nil, fmt.Errorf("amount must be a number greater than zero")
+		}
+		if tx.Amount != nil && amount > float64(*tx.Amount) {
+			return nil, fmt.Errorf("amount exceeds the transaction amount of %s", label)
+		}
+		refund := float32(amount)
+		body.Amount = &refund
+		label = currency.FormatPointers(&refund, tx.Currency)
+	}
+
+	transactionID := *tx.ID
+	code := util.StringOrDefault(tx.TransactionCode, transactionID)
+	return &pendingAction{
+		prompt: fmt.Sprintf("Refund %s of transaction %s?", label, code),
+		run: func() tea.Msg {
+			if m.appCtx.DryRun {
+				return actionMsg{text: fmt.Sprintf("Dry run, no request was sent: %s /v0.1/me/refund/%s", http.MethodPost, transactionID)}
+			}
+			err := m.appCtx.Client.Transactions.Refund(m.ctx, transactionID, body)
+			util.RecordAuditAction(m.cmd, "refund", m.merchantCode, transactionID, err)
+			if err != nil {
+				return actionMsg{err: fmt.Errorf("refund transaction: %w", err)}
+			}
+			return actionMsg{text: fmt.Sprintf("Refunded %s of transaction %s.", label, code)}
+		},
+	}, nil
+}
+
+// checkoutAction validates the amount of a checkout on the selected reader.
+func (m model) checkoutAction(value string) (*pendingAction, error) {
+	reader := m.readers[m.readerCursor]
+	parsedCurrency, err := currency.Parse(m.checkoutCurrency())
+	if err != nil {
+		return nil, err
+	}
+	decimals := currency.Decimals(parsedCurrency)
+	minor, err := currency.ToMinorUnits(value, decimals)
+	if err != nil {
+		return nil, err
+	}
+	if minor <= 0 {
+		return nil, fmt.Errorf("amount must be greater than zero")
+	}
+	if minor > int64(math.MaxInt32) {
+		return nil, fmt.Errorf("amount is too large to convert into minor units")
+	}
+
+	body := readers.CreateReaderCheckoutBody{
+		TotalAmount: readers.CreateReaderCheckoutBodyTotalAmount{
+			Currency:  currency.Code(parsedCurrency),
+			MinorUnit: int(decimals),
+			Value:     int(minor),
+		},
+	}
+	label := currency.Format(float64(minor)/math.Pow10(int(decimals)), parsedCurrency)
+	readerID := string(reader.ID)
+	return &pendingAction{
+		prompt: fmt.Sprintf("Charge %s on reader %s?", label, reader.Name),
+		run: func() tea.Msg {
+			if m.appCtx.DryRun {
+				return actionMsg{text: fmt.Sprintf("Dry run, no request was sent: %s /v0.1/merchants/%s/readers/%s/checkout", http.MethodPost, m.merchantCode, readerID)}
+			}
+			response, err := m.appCtx.Client.Readers.CreateCheckout(m.ctx, m.merchantCode, readerID, body)
+			if err != nil {
+				util.RecordAuditAction(m.cmd, "checkout", m.merchantCode, readerID, err)
+				return actionMsg{err: fmt.Errorf("trigger reader checkout: %w", err)}
+			}
+			util.RecordAuditAction(m.cmd, "checkout", m.merchantCode, response.Data.ClientTransactionId, nil)
+			return actionMsg{text: fmt.Sprintf("Checkout of %s sent to %s.", label, reader.Name)}
+		},
+	}, nil
+}
+
+// checkoutCurrency returns the currency for reader checkouts: the --currency
+// flag or the currency of the latest transaction.
+func (m model) checkoutCurrency() string {
+	if m.currency != "" {
+		return m.currency
+	}
+	for _, tx := range m.feed {
+		if tx.Currency != nil && *tx.Currency != "" {
+			return string(*tx.Currency)
+		}
+	}
+	return ""
+}
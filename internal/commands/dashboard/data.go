@@ -0,0 +1,210 @@
+package dashboard
+
+import (
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/sumup/sumup-go/datetime"
+	"github.com/sumup/sumup-go/payouts"
+	"github.com/sumup/sumup-go/readers"
+	"github.com/sumup/sumup-go/shared"
+	"github.com/sumup/sumup-go/transactions"
+
+	transactionscmd "github.com/sumup/sumup-cli/internal/commands/transactions"
+)
+
+const (
+	// feedSize is the number of transactions shown in the live feed.
+	feedSize = 20
+	// totalsPageSize and totalsMaxPages bound the requests made to sum up
+	// today's transactions.
+	totalsPageSize = 100
+	totalsMaxPages = 20
+	// payoutDays is how far ahead the payouts pane looks.
+	payoutDays = 14
+)
+
+// refreshIntervals controls how often each pane reloads its data.
+var refreshIntervals = map[pane]time.Duration{
+	paneTotals:  30 * time.Second,
+	paneFeed:    10 * time.Second,
+	paneReaders: 15 * time.Second,
+	panePayouts: 5 * time.Minute,
+}
+
+// tickMsg triggers the refresh of a pane.
+type tickMsg struct {
+	pane       pane
+	generation int
+}
+
+type totalsMsg struct {
+	totals *totals
+	err    error
+}
+
+type feedMsg struct {
+	items []transactions.TransactionHistory
+	err   error
+}
+
+type readersMsg struct {
+	items []readers.Reader
+	err   error
+}
+
+type payoutsMsg struct {
+	items []payouts.FinancialPayout
+	err   error
+}
+
+// currencyTotal sums amounts of one currency.
+type currencyTotal struct {
+	currency    shared.Currency
+	sales       float64
+	salesCount  int
+	refunds     float64
+	refundCount int
+}
+
+// totals summarises today's transactions.
+type totals struct {
+	byCurrency []currencyTotal
+	byStatus   map[transactions.TransactionHistoryStatus]int
+	truncated  bool
+}
+
+// tick schedules the next refresh of a pane and invalidates the ones
+// scheduled before.
+func (m *model) tick(p pane) tea.Cmd {
+	m.tickGeneration[p]++
+	generation := m.tickGeneration[p]
+	return tea.Tick(refreshIntervals[p], func(time.Time) tea.Msg {
+		return tickMsg{pane: p, generation: generation}
+	})
+}
+
+// fetch returns the command loading the data of a pane.
+func (m model) fetch(p pane) tea.Cmd {
+	switch p {
+	case paneTotals:
+		return m.fetchTotals
+	case paneFeed:
+		return m.fetchFeed
+	case paneReaders:
+		return m.fetchReaders
+	case panePayouts:
+		return m.fetchPayouts
+	}
+	return nil
+}
+
+func (m model) fetchTotals() tea.Msg {
+	midnight := startOfDay(time.Now())
+	limit := totalsPageSize
+	order := "asc"
+	params := transactions.ListTransactionsV21Params{
+		Limit:      &limit,
+		Order:      &order,
+		OldestTime: &midnight,
+	}
+
+	var items []transactions.TransactionHistory
+	truncated := true
+	for range totalsMaxPages {
+		response, err := m.appCtx.Client.Transactions.List(m.ctx, m.merchantCode, params)
+		if err != nil {
+			return totalsMsg{err: err}
+		}
+		items = append(items, response.Items...)
+
+		next, ok := transactionscmd.NextPageParams(params, response.Links)
+		if !ok || len(response.Items) == 0 {
+			truncated = false
+			break
+		}
+		params = next
+	}
+
+	result := summarize(items)
+	result.truncated = truncated
+	return totalsMsg{totals: result}
+}
+
+func summarize(items []transactions.TransactionHistory) *totals {
+	result := &totals{byStatus: map[transactions.TransactionHistoryStatus]int{}}
+	byCurrency := map[shared.Currency]*currencyTotal{}
+	for _, tx := range items {
+		if tx.Status != nil {
+			result.byStatus[*tx.Status]++
+		}
+		if tx.Amount == nil || tx.Currency == nil || tx.Status == nil || *tx.Status != transactions.TransactionHistoryStatusSuccessful {
+			continue
+		}
+		total, ok := byCurrency[*tx.Currency]
+		if !ok {
+			total = &currencyTotal{currency: *tx.Currency}
+			byCurrency[*tx.Currency] = total
+		}
+		if tx.Type != nil && *tx.Type == transactions.TransactionHistoryTypeRefund {
+			total.refunds += float64(*tx.Amount)
+			total.refundCount++
+			continue
+		}
+		total.sales += float64(*tx.Amount)
+		total.salesCount++
+	}
+
+	for _, total := range byCurrency {
+		result.byCurrency = append(result.byCurrency, *total)
+	}
+	sort.Slice(result.byCurrency, func(i, j int) bool {
+		return result.byCurrency[i].currency < result.byCurrency[j].currency
+	})
+	return result
+}
+
+func (m model) fetchFeed() tea.Msg {
+	limit := feedSize
+	order := "desc"
+	response, err := m.appCtx.Client.Transactions.List(m.ctx, m.merchantCode, transactions.ListTransactionsV21Params{
+		Limit: &limit,
+		Order: &order,
+	})
+	if err != nil {
+		return feedMsg{err: err}
+	}
+	return feedMsg{items: response.Items}
+}
+
+func (m model) fetchReaders() tea.Msg {
+	response, err := m.appCtx.Client.Readers.List(m.ctx, m.merchantCode)
+	if err != nil {
+		return readersMsg{err: err}
+	}
+	return readersMsg{items: response.Items}
+}
+
+func (m model) fetchPayouts() tea.Msg {
+	today := startOfDay(time.Now())
+	order := "asc"
+	response, err := m.appCtx.Client.Payouts.List(m.ctx, m.merchantCode, payouts.ListPayoutsV1Params{
+		StartDate: datetime.Date{Time: today},
+		EndDate:   datetime.Date{Time: today.AddDate(0, 0, payoutDays)},
+		Order:     &order,
+	})
+	if err != nil {
+		return payoutsMsg{err: err}
+	}
+	if response == nil {
+		return payoutsMsg{}
+	}
+	return payoutsMsg{items: *response}
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,239 @@
+package dashboard
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/sumup/sumup-go/transactions"
+
+	"github.com/sumup/sumup-cli/internal/commands/util"
+	"github.com/sumup/sumup-cli/internal/currency"
+	"github.com/sumup/sumup-cli/internal/display"
+)
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("6"))
+	paneStyle     = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("241")).Padding(0, 1)
+	focusedStyle  = paneStyle.BorderForeground(display.SumUpPink)
+	selectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("2")).Bold(true)
+	faintStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+)
+
+func (m model) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+
+	header := titleStyle.Render(fmt.Sprintf("SumUp dashboard · %s", m.merchantCode)) +
+		faintStyle.Render(" · "+time.Now().Format("Mon 2 Jan 15:04"))
+
+	// Two rows of panes between the header and the two footer lines.
+	paneWidth := m.width / 2
+	paneHeight := max(6, (m.height-3)/2)
+
+	top := lipgloss.JoinHorizontal(lipgloss.Top,
+		m.renderPane("Today", m.totalsLines(), m.totalsErr, m.totalsUpdated, paneWidth, paneHeight, false),
+		m.renderPane(fmt.Sprintf("Payouts · next %d days", payoutDays), m.payoutLines(), m.payoutsErr, m.payoutsUpdated, m.width-paneWidth, paneHeight, false),
+	)
+	bottom := lipgloss.JoinHorizontal(lipgloss.Top,
+		m.renderPane("Transactions", m.feedLines(), m.feedErr, m.feedUpdated, paneWidth, paneHeight, m.focus == paneFeed),
+		m.renderPane("Readers", m.readerLines(), m.readersErr, m.readersUpdated, m.width-paneWidth, paneHeight, m.focus == paneReaders),
+	)
+
+	body := lipgloss.JoinVertical(lipgloss.Left, top, bottom)
+	if m.mode == modeDetails {
+		body = m.renderOverlay()
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, header, body, m.footer())
+}
+
+// renderPane draws a bordered pane of the given outer size. Lines that do not
+// fit are cut off.
+func (m model) renderPane(title string, lines []string, err error, updated time.Time, width, height int, focused bool) string {
+	style := paneStyle
+	if focused {
+		style = focusedStyle
+	}
+	innerWidth := max(1, width-style.GetHorizontalFrameSize())
+	innerHeight := max(1, height-style.GetVerticalFrameSize())
+
+	heading := titleStyle.Render(title)
+	switch {
+	case err != nil:
+		lines = []string{errorStyle.Render(err.Error())}
+	case updated.IsZero():
+		lines = []string{faintStyle.Render("Loading...")}
+	default:
+		heading += faintStyle.Render(" · " + updated.Format(time.TimeOnly))
+	}
+
+	content := append([]string{heading}, lines...)
+	if len(content) > innerHeight {
+		content = content[:innerHeight]
+	}
+	truncate := lipgloss.NewStyle().MaxWidth(innerWidth)
+	for i, line := range content {
+		content[i] = truncate.Render(line)
+	}
+
+	return style.Width(innerWidth).Height(innerHeight).Render(strings.Join(content, "\n"))
+}
+
+func (m model) totalsLines() []string {
+	if m.totals == nil {
+		return nil
+	}
+	var lines []string
+	if len(m.totals.byCurrency) == 0 {
+		lines = append(lines, "No sales yet today.")
+	}
+	for _, total := range m.totals.byCurrency {
+		lines = append(lines, fmt.Sprintf("Sales    %s (%d)", currency.Format(total.sales, total.currency), total.salesCount))
+		if total.refundCount > 0 {
+			lines = append(lines, fmt.Sprintf("Refunds  %s (%d)", currency.Format(total.refunds, total.currency), total.refundCount))
+		}
+	}
+
+	statuses := make([]string, 0, len(m.totals.byStatus))
+	for status := range m.totals.byStatus {
+		statuses = append(statuses, string(status))
+	}
+	sort.Strings(statuses)
+	if len(statuses) > 0 {
+		lines = append(lines, "")
+	}
+	for _, status := range statuses {
+		count := m.totals.byStatus[transactions.TransactionHistoryStatus(status)]
+		lines = append(lines, fmt.Sprintf("%-10s %d", strings.ToLower(status), count))
+	}
+	if m.totals.truncated {
+		lines = append(lines, faintStyle.Render(fmt.Sprintf("Only the first %d transactions are counted.", totalsPageSize*totalsMaxPages)))
+	}
+	return lines
+}
+
+func (m model) feedLines() []string {
+	if len(m.feed) == 0 {
+		return []string{"No transactions."}
+	}
+	lines := make([]string, 0, len(m.feed))
+	for i, tx := range m.feed {
+		timestamp := "-"
+		if tx.Timestamp != nil {
+			timestamp = tx.Timestamp.Local().Format("02 Jan 15:04")
+		}
+		status := "-"
+		if tx.Status != nil {
+			status = strings.ToLower(string(*tx.Status))
+		}
+		kind := "-"
+		if tx.Type != nil {
+			kind = strings.ToLower(string(*tx.Type))
+		}
+		line := fmt.Sprintf("%s  %-12s %12s  %-10s %s",
+			timestamp,
+			util.StringOrDefault(tx.TransactionCode, "-"),
+			currency.FormatPointers(tx.Amount, tx.Currency),
+			status,
+			kind,
+		)
+		lines = append(lines, m.cursorLine(line, i == m.feedCursor && m.focus == paneFeed))
+	}
+	return lines
+}
+
+func (m model) readerLines() []string {
+	if len(m.readers) == 0 {
+		return []string{"No readers paired."}
+	}
+	lines := make([]string, 0, len(m.readers))
+	for i, reader := range m.readers {
+		line := fmt.Sprintf("%-24s %-10s %s", reader.Name, reader.Status, reader.Device.Model)
+		lines = append(lines, m.cursorLine(line, i == m.readerCursor && m.focus == paneReaders))
+	}
+	return lines
+}
+
+func (m model) payoutLines() []string {
+	if len(m.payouts) == 0 {
+		return []string{"No upcoming payouts."}
+	}
+	lines := make([]string, 0, len(m.payouts))
+	for _, payout := range m.payouts {
+		date := "-"
+		if payout.Date != nil {
+			date = payout.Date.String()
+		}
+		amount := "-"
+		if payout.Amount != nil {
+			amount = fmt.Sprintf("%.2f", *payout.Amount)
+			if payout.Currency != nil {
+				if parsed, err := currency.Parse(*payout.Currency); err == nil {
+					amount = currency.Format(float64(*payout.Amount), parsed)
+				}
+			}
+		}
+		status := "-"
+		if payout.Status != nil {
+			status = strings.ToLower(string(*payout.Status))
+		}
+		lines = append(lines, fmt.Sprintf("%s  %12s  %s", date, amount, status))
+	}
+	return lines
+}
+
+func (m model) cursorLine(line string, selected bool) string {
+	if selected {
+		return selectedStyle.Render("> " + line)
+	}
+	return "  " + line
+}
+
+func (m model) renderOverlay() string {
+	content := m.details
+	if content == "" {
+		content = faintStyle.Render("Loading transaction...")
+	}
+	content = titleStyle.Render("Transaction details") + "\n\n" + strings.TrimRight(content, "\n")
+	return focusedStyle.Width(max(1, m.width-focusedStyle.GetHorizontalFrameSize())).
+		Height(max(1, m.height-3-focusedStyle.GetVerticalFrameSize())).
+		Render(content)
+}
+
+func (m model) footer() string {
+	var prompt string
+	switch m.mode {
+	case modeRefund:
+		prompt = "Refund amount (empty for full refund): " + m.amountInput.View()
+	case modeCheckout:
+		prompt = fmt.Sprintf("Checkout amount in %s: %s", m.checkoutCurrency(), m.amountInput.View())
+	case modeConfirm:
+		prompt = m.pending.prompt + " (y/n)"
+	default:
+		switch {
+		case m.status == "":
+		case m.statusErr:
+			prompt = errorStyle.Render(m.status)
+		default:
+			prompt = m.status
+		}
+	}
+
+	var help string
+	switch m.mode {
+	case modeDetails:
+		help = "r: refund | esc: back | ctrl+c: quit"
+	case modeRefund, modeCheckout:
+		help = "enter: continue | esc: cancel"
+	case modeConfirm:
+		help = "y: confirm | n: cancel"
+	default:
+		help = "tab: switch pane | ↑/↓: select | enter: details | r: refund | c: reader checkout | R: refresh | q: quit"
+	}
+	return prompt + "\n" + faintStyle.Render(help)
+}
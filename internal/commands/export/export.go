@@ -0,0 +1,326 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/urfave/cli/v3"
+
+	"github.com/sumup/sumup-go/payouts"
+	"github.com/sumup/sumup-go/transactions"
+
+	"github.com/sumup/sumup-cli/internal/accounting"
+	"github.com/sumup/sumup-cli/internal/app"
+	payoutscmd "github.com/sumup/sumup-cli/internal/commands/payouts"
+	transactionscmd "github.com/sumup/sumup-cli/internal/commands/transactions"
+	"github.com/sumup/sumup-cli/internal/commands/util"
+	"github.com/sumup/sumup-cli/internal/display"
+	"github.com/sumup/sumup-cli/internal/display/message"
+)
+
+// transactionsPageSize is the page size used to collect transactions.
+const transactionsPageSize = 100
+
+// excludedStatuses are the transactions that moved no money.
+var excludedStatuses = []transactions.TransactionHistoryStatus{
+	transactions.TransactionHistoryStatusFailed,
+	transactions.TransactionHistoryStatusCancelled,
+	transactions.TransactionHistoryStatusPending,
+}
+
+func NewCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "Export merchant data for other tools.",
+		Commands: []*cli.Command{
+			{
+				Name:   "accounting",
+				Usage:  "Export sales, refunds, fees and payouts as journal entries for bookkeeping software.",
+				Action: exportAccounting,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "merchant-code",
+						Usage:   "Merchant code to export. Falls back to context.",
+						Sources: cli.EnvVars("SUMUP_MERCHANT_CODE"),
+					},
+					&cli.StringFlag{
+						Name:     "format",
+						Usage:    "Export format: " + strings.Join(accounting.Formats(), ", ") + ".",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "from",
+						Usage:    "First day to export in YYYY-MM-DD format.",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "to",
+						Usage:    "Last day to export in YYYY-MM-DD format.",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "mapping",
+						Usage: "YAML file with the accounts and tax codes to book to. Defaults to the SKR03 chart of accounts.",
+					},
+					&cli.StringFlag{
+						Name:  "output",
+						Usage: "Write the export to this file instead of standard output.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func exportAccounting(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		return err
+	}
+
+	format := strings.ToLower(cmd.String("format"))
+	if !slices.Contains(accounting.Formats(), format) {
+		return fmt.Errorf("unsupported format %q, expected one of %s", cmd.String("format"), strings.Join(accounting.Formats(), ", "))
+	}
+
+	period, err := parsePeriod(cmd.String("from"), cmd.String("to"))
+	if err != nil {
+		return err
+	}
+
+	mapping, err := accounting.LoadMapping(cmd.String("mapping"))
+	if err != nil {
+		return err
+	}
+	if err := mapping.Check(format, period); err != nil {
+		return err
+	}
+
+	history, err := listTransactions(ctx, appCtx, merchantCode, period)
+	if err != nil {
+		return err
+	}
+	payoutList, err := payoutscmd.ListAll(ctx, appCtx, merchantCode, period.From, period.To)
+	if err != nil {
+		return err
+	}
+
+	entries := transactionEntries(mapping, history)
+	entries = append(entries, payoutEntries(mapping, payoutList)...)
+	slices.SortStableFunc(entries, func(a, b accounting.Entry) int {
+		return a.Date.Compare(b.Date)
+	})
+
+	if appCtx.JSONOutput {
+		return display.PrintJSON(entries)
+	}
+
+	var w io.Writer = os.Stdout
+	path := cmd.String("output")
+	if path != "" {
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("create %s: %w", path, err)
+		}
+		defer file.Close()
+		w = file
+	}
+	if err := accounting.Write(w, format, mapping, period, entries); err != nil {
+		return fmt.Errorf("write %s export: %w", format, err)
+	}
+	if path != "" {
+		message.Success("Wrote %d entries to %s", len(entries), path)
+	}
+	return nil
+}
+
+func parsePeriod(from, to string) (accounting.Period, error) {
+	start, err := time.ParseInLocation(time.DateOnly, from, time.Local)
+	if err != nil {
+		return accounting.Period{}, fmt.Errorf("invalid date %q: %w", from, err)
+	}
+	end, err := time.ParseInLocation(time.DateOnly, to, time.Local)
+	if err != nil {
+		return accounting.Period{}, fmt.Errorf("invalid date %q: %w", to, err)
+	}
+	if end.Before(start) {
+		return accounting.Period{}, fmt.Errorf("--to %s is before --from %s", to, from)
+	}
+	return accounting.Period{From: start, To: end}, nil
+}
+
+// listTransactions collects the payments, refunds and chargebacks of the
+// period, following the pages of the transaction history. The status of a
+// transaction is its final state, so sales that were refunded or charged back
+// later are kept and only transactions that never went through are dropped.
+func listTransactions(ctx context.Context, appCtx *app.Context, merchantCode string, period accounting.Period) ([]transactions.TransactionHistory, error) {
+	limit := transactionsPageSize
+	order := "asc"
+	oldest := period.From
+	newest := period.To.AddDate(0, 0, 1).Add(-time.Second)
+	params := transactions.ListTransactionsV21Params{
+		Limit:      &limit,
+		Order:      &order,
+		OldestTime: &oldest,
+		NewestTime: &newest,
+		Types: []string{
+			string(transactions.TransactionHistoryTypePayment),
+			string(transactions.TransactionHistoryTypeRefund),
+			string(transactions.TransactionHistoryTypeChargeBack),
+		},
+	}
+
+	var items []transactions.TransactionHistory
+	for {
+		response, err := appCtx.Client.Transactions.List(ctx, merchantCode, params)
+		if err != nil {
+			return nil, fmt.Errorf("list transactions: %w", err)
+		}
+		for _, item := range response.Items {
+			if item.Status != nil && slices.Contains(excludedStatuses, *item.Status) {
+				continue
+			}
+			items = append(items, item)
+		}
+
+		next, ok := transactionscmd.NextPageParams(params, response.Links)
+		if !ok || len(response.Items) == 0 {
+			return items, nil
+		}
+		params = next
+	}
+}
+
+// transactionEntries books sales to the sales account and refunds and
+// chargebacks against their accounts, all through the clearing account
+// where the money stays until SumUp pays it out.
+func transactionEntries(mapping accounting.Mapping, history []transactions.TransactionHistory) []accounting.Entry {
+	entries := make([]accounting.Entry, 0, len(history))
+	for _, tx := range history {
+		if tx.Amount == nil || tx.Timestamp == nil || tx.Type == nil {
+			continue
+		}
+		code := util.StringOrDefault(tx.TransactionCode, util.StringOrDefault(tx.ID, ""))
+		entry := accounting.Entry{
+			Date:        tx.Timestamp.In(time.Local),
+			Reference:   code,
+			Description: util.StringOrDefault(tx.ProductSummary, ""),
+			Amount:      decimal.NewFromFloat32(*tx.Amount).Abs(),
+		}
+		if tx.Currency != nil {
+			entry.Currency = string(*tx.Currency)
+		}
+
+		switch *tx.Type {
+		case transactions.TransactionHistoryTypePayment:
+			entry.Kind = accounting.KindSale
+			entry.Debit, entry.Credit = mapping.Accounts.Clearing, mapping.Accounts.Sales
+			entry.TaxCode = mapping.TaxCodes.Sales
+		case transactions.TransactionHistoryTypeRefund:
+			entry.Kind = accounting.KindRefund
+			entry.Debit, entry.Credit = mapping.Accounts.Refunds, mapping.Accounts.Clearing
+			entry.TaxCode = mapping.TaxCodes.Refunds
+		case transactions.TransactionHistoryTypeChargeBack:
+			entry.Kind = accounting.KindChargeback
+			entry.Debit, entry.Credit = mapping.Accounts.Chargebacks, mapping.Accounts.Clearing
+			entry.TaxCode = mapping.TaxCodes.Chargebacks
+		default:
+			continue
+		}
+		// Refunds and chargebacks carry the code of the sale, which several
+		// partial refunds share, so they are referenced by their own ID.
+		if entry.Kind != accounting.KindSale {
+			entry.Reference = util.StringOrDefault(tx.ID, code)
+		}
+		if entry.Description == "" {
+			entry.Description = "SumUp " + entry.Kind + " " + code
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// payoutEntries books the fees withheld from each payout and the transfer of
+// the rest to the bank account. Payouts list one line per settled
+// transaction, so the lines are summed per payout.
+func payoutEntries(mapping accounting.Mapping, list payouts.FinancialPayouts) []accounting.Entry {
+	type total struct {
+		date     time.Time
+		currency string
+		amount   decimal.Decimal
+		fee      decimal.Decimal
+	}
+	var ids []int
+	totals := map[int]*total{}
+	for _, line := range list {
+		if line.ID == nil || line.Date == nil || (line.Status != nil && *line.Status == payouts.FinancialPayoutStatusFailed) {
+			continue
+		}
+		t, ok := totals[*line.ID]
+		if !ok {
+			t = &total{date: time.Date(line.Date.Year(), line.Date.Month(), line.Date.Day(), 0, 0, 0, 0, time.Local)}
+			totals[*line.ID] = t
+			ids = append(ids, *line.ID)
+		}
+		if line.Currency != nil {
+			t.currency = *line.Currency
+		}
+		if line.Amount != nil {
+			t.amount = t.amount.Add(decimal.NewFromFloat32(*line.Amount))
+		}
+		if line.Fee != nil {
+			t.fee = t.fee.Add(decimal.NewFromFloat32(*line.Fee))
+		}
+	}
+
+	var entries []accounting.Entry
+	for _, id := range ids {
+		t := totals[id]
+		reference := strconv.Itoa(id)
+		if !t.fee.IsZero() {
+			entries = append(entries, accounting.Entry{
+				Date:        t.date,
+				Kind:        accounting.KindFee,
+				Reference:   reference,
+				Description: "SumUp fees payout " + reference,
+				Amount:      t.fee.Abs(),
+				Currency:    t.currency,
+				Debit:       mapping.Accounts.Fees,
+				Credit:      mapping.Accounts.Clearing,
+				TaxCode:     mapping.TaxCodes.Fees,
+			})
+		}
+		if t.amount.IsZero() {
+			continue
+		}
+		entry := accounting.Entry{
+			Date:        t.date,
+			Kind:        accounting.KindPayout,
+			Reference:   reference,
+			Description: "SumUp payout " + reference,
+			Amount:      t.amount.Abs(),
+			Currency:    t.currency,
+			Debit:       mapping.Accounts.Bank,
+			Credit:      mapping.Accounts.Clearing,
+		}
+		// Deductions larger than the sales of a payout are collected from
+		// the bank account.
+		if t.amount.IsNegative() {
+			entry.Debit, entry.Credit = entry.Credit, entry.Debit
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
@@ -0,0 +1,45 @@
+package export
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sumup/sumup-go/transactions"
+
+	"github.com/sumup/sumup-cli/internal/accounting"
+)
+
+func TestTransactionEntries(t *testing.T) {
+	history := func(id string, kind transactions.TransactionHistoryType, amount float32) transactions.TransactionHistory {
+		code := "TEENSK4W2K"
+		timestamp := time.Date(2026, 10, 2, 12, 0, 0, 0, time.UTC)
+		return transactions.TransactionHistory{ID: &id, TransactionCode: &code, Type: &kind, Amount: &amount, Timestamp: &timestamp}
+	}
+
+	entries := transactionEntries(accounting.DefaultMapping(), []transactions.TransactionHistory{
+		history("tx-sale", transactions.TransactionHistoryTypePayment, 12.5),
+		history("tx-refund-1", transactions.TransactionHistoryTypeRefund, -2.5),
+		history("tx-refund-2", transactions.TransactionHistoryTypeRefund, -1),
+		history("tx-chargeback", transactions.TransactionHistoryTypeChargeBack, -9),
+	})
+
+	want := []struct {
+		kind        string
+		reference   string
+		description string
+	}{
+		{kind: accounting.KindSale, reference: "TEENSK4W2K", description: "SumUp sale TEENSK4W2K"},
+		{kind: accounting.KindRefund, reference: "tx-refund-1", description: "SumUp refund TEENSK4W2K"},
+		{kind: accounting.KindRefund, reference: "tx-refund-2", description: "SumUp refund TEENSK4W2K"},
+		{kind: accounting.KindChargeback, reference: "tx-chargeback", description: "SumUp chargeback TEENSK4W2K"},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+	for i, w := range want {
+		got := entries[i]
+		if got.Kind != w.kind || got.Reference != w.reference || got.Description != w.description {
+			t.Errorf("entry %d = %s %q %q, want %s %q %q", i, got.Kind, got.Reference, got.Description, w.kind, w.reference, w.description)
+		}
+	}
+}
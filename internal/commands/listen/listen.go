@@ -0,0 +1,279 @@
+package listen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/commands/util"
+	"github.com/sumup/sumup-cli/internal/display"
+	"github.com/sumup/sumup-cli/internal/display/message"
+	"github.com/sumup/sumup-cli/internal/webhook"
+)
+
+const (
+	forwardTimeout = 30 * time.Second
+	// maxEventSize bounds the body of a received request.
+	maxEventSize = 1 << 20
+)
+
+// hopHeaders are connection-specific and must not be copied when forwarding.
+var hopHeaders = []string{
+	"Connection",
+	"Content-Length",
+	"Keep-Alive",
+	"Proxy-Connection",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+func NewCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "listen",
+		Usage: "Receive SumUp callbacks locally and optionally forward them.",
+		Description: `Examples:
+  sumup listen --port 8080
+  sumup listen --port 8080 --forward-to http://localhost:3000/hooks --public-url https://example.ngrok.app`,
+		Action: listen,
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "port",
+				Usage: "Local port the receiver listens on.",
+				Value: 8080,
+			},
+			&cli.StringFlag{
+				Name:  "forward-to",
+				Usage: "URL that received events are forwarded to, with their original headers.",
+			},
+			&cli.StringFlag{
+				Name:  "public-url",
+				Usage: "Publicly reachable URL of the receiver, for example a tunnel. Used by --listen on checkout commands.",
+			},
+		},
+		Commands: []*cli.Command{
+			{
+				Name:      "replay",
+				Usage:     "Forward a previously received event again.",
+				Action:    replayEvent,
+				ArgsUsage: "<event-id>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "forward-to",
+						Usage:    "URL that the event is forwarded to.",
+						Required: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func listen(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	forwardTo := cmd.String("forward-to")
+	addr := net.JoinHostPort("localhost", strconv.Itoa(cmd.Int("port")))
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", addr, err)
+	}
+
+	url := cmd.String("public-url")
+	if url == "" {
+		url = "http://" + addr
+	}
+	if err := webhook.SetListenerURL(url); err != nil {
+		return err
+	}
+	defer func() {
+		if err := webhook.ClearListenerURL(); err != nil {
+			message.Warn("%v", err)
+		}
+	}()
+
+	client := &http.Client{Timeout: forwardTimeout}
+	server := &http.Server{
+		ReadHeaderTimeout: 10 * time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			event, err := captureEvent(w, r)
+			if err != nil {
+				message.Error("%v", err)
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+					return
+				}
+				http.Error(w, "failed to read request", http.StatusBadRequest)
+				return
+			}
+			if err := webhook.AppendHistory(*event); err != nil {
+				message.Warn("Failed to store event: %v", err)
+			}
+			printEvent(appCtx, event)
+
+			if forwardTo != "" {
+				forward(r.Context(), client, forwardTo, event)
+			}
+			w.WriteHeader(http.StatusOK)
+		}),
+	}
+
+	// Stop on SIGTERM as well, so the listener URL is cleared when the
+	// listener runs under a process manager.
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(listener)
+	}()
+
+	message.Success("Listening on %s", url)
+	if forwardTo != "" {
+		message.Notify("Forwarding events to %s", forwardTo)
+	}
+	if url == "http://"+addr {
+		message.Notify("SumUp cannot reach localhost. Expose the port through a tunnel and pass --public-url to use it as a return URL.")
+	}
+	message.Notify("Press Ctrl+C to stop.")
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("serve: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shut down listener: %w", err)
+	}
+	return nil
+}
+
+func replayEvent(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	eventID, err := util.RequireSingleArg(cmd, "event ID")
+	if err != nil {
+		return err
+	}
+
+	event, err := webhook.FindEvent(eventID)
+	if err != nil {
+		return fmt.Errorf("find event %q: %w", eventID, err)
+	}
+
+	printEvent(appCtx, event)
+
+	client := &http.Client{Timeout: forwardTimeout}
+	status, err := send(ctx, client, cmd.String("forward-to"), event)
+	if err != nil {
+		return fmt.Errorf("replay event: %w", err)
+	}
+
+	message.Success("Replayed %s to %s (%s)", event.ID, cmd.String("forward-to"), status)
+	return nil
+}
+
+func captureEvent(w http.ResponseWriter, r *http.Request) (*webhook.Event, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxEventSize))
+	if err != nil {
+		return nil, fmt.Errorf("read request body: %w", err)
+	}
+
+	id, err := webhook.NewEventID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhook.Event{
+		ID:         id,
+		ReceivedAt: time.Now().UTC(),
+		Method:     r.Method,
+		Path:       r.URL.RequestURI(),
+		Header:     r.Header.Clone(),
+		Body:       string(body),
+	}, nil
+}
+
+func printEvent(appCtx *app.Context, event *webhook.Event) {
+	if appCtx.JSONOutput {
+		if err := display.PrintJSON(event); err != nil {
+			message.Error("%v", err)
+		}
+		return
+	}
+
+	message.Notify("%s %s %s (%s)", event.ReceivedAt.Local().Format(time.TimeOnly), event.Method, event.Path, event.ID)
+	if event.Body == "" {
+		return
+	}
+
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, []byte(event.Body), "", "  "); err != nil {
+		fmt.Println(event.Body)
+		return
+	}
+	fmt.Println(pretty.String())
+}
+
+func forward(ctx context.Context, client *http.Client, target string, event *webhook.Event) {
+	status, err := send(ctx, client, target, event)
+	if err != nil {
+		message.Error("Forward %s: %v", event.ID, err)
+		return
+	}
+	message.Success("Forwarded %s to %s (%s)", event.ID, target, status)
+}
+
+// send delivers the event to the target URL with its original headers.
+func send(ctx context.Context, client *http.Client, target string, event *webhook.Event) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, event.Method, target, strings.NewReader(event.Body))
+	if err != nil {
+		return "", fmt.Errorf("build request: %w", err)
+	}
+	for key, values := range event.Header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	for _, key := range hopHeaders {
+		req.Header.Del(key)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return "", fmt.Errorf("unexpected response %s", resp.Status)
+	}
+	return resp.Status, nil
+}
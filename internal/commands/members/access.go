@@ -0,0 +1,357 @@
+package members
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/sumup/sumup-go/members"
+	"github.com/sumup/sumup-go/shared"
+
+	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/commands/util"
+	"github.com/sumup/sumup-cli/internal/display"
+	"github.com/sumup/sumup-cli/internal/display/attribute"
+	"github.com/sumup/sumup-cli/internal/display/message"
+)
+
+// memberAccess is the membership of a user in one merchant.
+type memberAccess struct {
+	MerchantCode string   `json:"merchant_code"`
+	MerchantName string   `json:"merchant_name,omitempty"`
+	Email        string   `json:"email"`
+	MemberID     string   `json:"member_id"`
+	Roles        []string `json:"roles"`
+	Status       string   `json:"status"`
+}
+
+func offboardCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "offboard",
+		Usage:     "Remove a user from every merchant they are a member of.",
+		ArgsUsage: "<email>",
+		Action:    offboardMember,
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:    "merchant-code",
+				Usage:   "Merchant code to remove the user from when neither --org nor --all-merchants is set. Falls back to context.",
+				Sources: cli.EnvVars("SUMUP_MERCHANT_CODE"),
+			},
+		}, util.FanOutFlags()...),
+	}
+}
+
+func accessReportCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "access-report",
+		Usage:  "Show which users can access which merchants, with their roles.",
+		Action: accessReport,
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:    "merchant-code",
+				Usage:   "Merchant code to report on when neither --org nor --all-merchants is set. Falls back to context.",
+				Sources: cli.EnvVars("SUMUP_MERCHANT_CODE"),
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "Output format: table, or csv for a matrix of users and merchants.",
+				Value: "table",
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Write the CSV to this file instead of standard output.",
+			},
+		}, util.FanOutFlags()...),
+	}
+}
+
+func offboardMember(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	email, err := util.RequireSingleArg(cmd, "email")
+	if err != nil {
+		return err
+	}
+
+	merchants, err := targetMerchants(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	lookups := util.FanOut(ctx, cmd, merchants, func(ctx context.Context, merchantCode string) ([]members.Member, error) {
+		response, err := appCtx.Client.Members.List(ctx, merchantCode, members.ListMerchantMembersParams{Email: &email})
+		if err != nil {
+			return nil, fmt.Errorf("list members: %w", err)
+		}
+		// The email filter matches prefixes.
+		var found []members.Member
+		for _, member := range response.Items {
+			if strings.EqualFold(memberEmail(member), email) {
+				found = append(found, member)
+			}
+		}
+		return found, nil
+	})
+
+	var accesses []memberAccess
+	for _, lookup := range lookups {
+		for _, member := range lookup.Result {
+			accesses = append(accesses, accessOf(lookup.MerchantCode, lookup.MerchantName, member))
+		}
+	}
+	lookupErr := util.FanOutErrors(appCtx, lookups)
+	if lookupErr != nil && !appCtx.JSONOutput {
+		message.Warn("%s may have access to merchants that could not be checked.", email)
+	}
+
+	if len(accesses) == 0 {
+		if lookupErr != nil {
+			return lookupErr
+		}
+		if appCtx.JSONOutput {
+			return display.PrintJSON([]memberAccess{})
+		}
+		message.Notify("%s is not a member of any of the %d merchants.", email, len(merchants))
+		return nil
+	}
+
+	if !appCtx.JSONOutput {
+		renderAccesses(fmt.Sprintf("Access of %s", email), accesses)
+	}
+
+	if appCtx.DryRun {
+		for _, access := range accesses {
+			if err := util.DryRun(appCtx, http.MethodDelete, membersPath(access.MerchantCode)+"/"+access.MemberID, nil); err != nil {
+				return err
+			}
+		}
+		return lookupErr
+	}
+
+	err = util.Confirm(appCtx, util.Confirmation{
+		Action: fmt.Sprintf("remove %s from %d merchants", email, len(accesses)),
+		Name:   email,
+		Details: []attribute.KeyValue{
+			attribute.Attribute("Email", attribute.Styled(email)),
+			attribute.Attribute("Merchants", attribute.Styled(fmt.Sprint(len(accesses)))),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	byMerchant := map[string][]memberAccess{}
+	targets := make([]util.Merchant, 0, len(accesses))
+	for _, access := range accesses {
+		if _, ok := byMerchant[access.MerchantCode]; !ok {
+			targets = append(targets, util.Merchant{Code: access.MerchantCode, Name: access.MerchantName})
+		}
+		byMerchant[access.MerchantCode] = append(byMerchant[access.MerchantCode], access)
+	}
+	results := util.FanOut(ctx, cmd, targets, func(ctx context.Context, merchantCode string) ([]string, error) {
+		var removed []string
+		for _, access := range byMerchant[merchantCode] {
+			err := appCtx.Client.Members.Delete(ctx, merchantCode, access.MemberID)
+			util.RecordAudit(cmd, merchantCode, access.MemberID, err)
+			if err != nil {
+				return removed, fmt.Errorf("delete member %s: %w", access.MemberID, err)
+			}
+			removed = append(removed, access.MemberID)
+		}
+		return removed, nil
+	})
+
+	if appCtx.JSONOutput {
+		if err := display.PrintJSON(results); err != nil {
+			return err
+		}
+	}
+	if err := util.FanOutErrors(appCtx, results); err != nil {
+		return err
+	}
+	if !appCtx.JSONOutput {
+		message.Success("Removed %s from %d merchants", email, len(targets))
+	}
+	return lookupErr
+}
+
+func accessReport(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	format := cmd.String("format")
+	if format != "table" && format != "csv" {
+		return fmt.Errorf("unsupported format %q, expected table or csv", format)
+	}
+
+	merchants, err := targetMerchants(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	results := util.FanOut(ctx, cmd, merchants, func(ctx context.Context, merchantCode string) ([]members.Member, error) {
+		return listAllMembers(ctx, appCtx, merchantCode)
+	})
+
+	var accesses []memberAccess
+	for _, result := range results {
+		for _, member := range result.Result {
+			accesses = append(accesses, accessOf(result.MerchantCode, result.MerchantName, member))
+		}
+	}
+
+	switch {
+	case appCtx.JSONOutput:
+		if err := display.PrintJSON(accesses); err != nil {
+			return err
+		}
+	case format == "csv":
+		if err := writeAccessReport(cmd.String("output"), merchants, accesses); err != nil {
+			return err
+		}
+	default:
+		// A merchant column per merchant gets too wide for a terminal, so
+		// the table lists the accesses grouped by user instead.
+		slices.SortStableFunc(accesses, func(a, b memberAccess) int {
+			return strings.Compare(strings.ToLower(a.Email), strings.ToLower(b.Email))
+		})
+		rows := make([][]string, 0, len(accesses))
+		for _, access := range accesses {
+			rows = append(rows, []string{access.Email, access.MerchantCode, dashIfEmpty(access.MerchantName), memberRoles(access.Roles), access.Status})
+		}
+		display.RenderTable("Access report", []string{"Email", "Merchant", "Name", "Roles", "Status"}, rows)
+	}
+	return util.FanOutErrors(appCtx, results)
+}
+
+// targetMerchants returns the merchants selected with --org or
+// --all-merchants, or the merchant from --merchant-code or the context.
+func targetMerchants(ctx context.Context, cmd *cli.Command) ([]util.Merchant, error) {
+	merchants, fanOut, err := util.FanOutMerchants(ctx, cmd)
+	if err != nil || fanOut {
+		return merchants, err
+	}
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		return nil, err
+	}
+	return []util.Merchant{{Code: merchantCode}}, nil
+}
+
+func accessOf(merchantCode, merchantName string, member members.Member) memberAccess {
+	return memberAccess{
+		MerchantCode: merchantCode,
+		MerchantName: merchantName,
+		Email:        memberEmail(member),
+		MemberID:     member.ID,
+		Roles:        member.Roles,
+		Status:       membershipStatusLabel(member.Status),
+	}
+}
+
+func renderAccesses(title string, accesses []memberAccess) {
+	rows := make([][]string, 0, len(accesses))
+	for _, access := range accesses {
+		rows = append(rows, []string{
+			access.MerchantCode,
+			dashIfEmpty(access.MerchantName),
+			access.MemberID,
+			memberRoles(access.Roles),
+			access.Status,
+		})
+	}
+	display.RenderTable(title, []string{"Merchant", "Name", "Member ID", "Roles", "Status"}, rows)
+}
+
+// accessMatrix lays out the accesses with one row per user and one column per
+// merchant, holding the roles of the user. Pending and expired memberships
+// are marked, since they do not grant access yet.
+func accessMatrix(merchants []util.Merchant, accesses []memberAccess) ([]string, [][]string) {
+	headers := make([]string, 0, len(merchants)+1)
+	headers = append(headers, "Email")
+	column := make(map[string]int, len(merchants))
+	for i, merchant := range merchants {
+		column[merchant.Code] = i + 1
+		headers = append(headers, merchant.Code)
+	}
+
+	byEmail := map[string][]string{}
+	var emails []string
+	for _, access := range accesses {
+		key := strings.ToLower(access.Email)
+		row, ok := byEmail[key]
+		if !ok {
+			row = make([]string, len(headers))
+			row[0] = access.Email
+			emails = append(emails, key)
+		}
+		cell := strings.Join(access.Roles, ";")
+		if access.Status != membershipStatusLabel(shared.MembershipStatusAccepted) {
+			cell += " (" + strings.ToLower(access.Status) + ")"
+		}
+		row[column[access.MerchantCode]] = cell
+		byEmail[key] = row
+	}
+	slices.Sort(emails)
+
+	rows := make([][]string, 0, len(emails))
+	for _, email := range emails {
+		row := byEmail[email]
+		for i := range row {
+			if row[i] == "" {
+				row[i] = "-"
+			}
+		}
+		rows = append(rows, row)
+	}
+	return headers, rows
+}
+
+// writeAccessReport writes the access matrix as CSV, with the merchant names,
+// when known, in a second header row.
+func writeAccessReport(path string, merchants []util.Merchant, accesses []memberAccess) error {
+	var w io.Writer = os.Stdout
+	if path != "" {
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("create %s: %w", path, err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	headers, rows := accessMatrix(merchants, accesses)
+	names := make([]string, 0, len(headers))
+	names = append(names, "")
+	named := false
+	for _, merchant := range merchants {
+		names = append(names, merchant.Name)
+		named = named || merchant.Name != ""
+	}
+
+	writer := csv.NewWriter(w)
+	_ = writer.Write(headers)
+	if named {
+		_ = writer.Write(names)
+	}
+	_ = writer.WriteAll(rows)
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("write access report: %w", err)
+	}
+	if path != "" {
+		message.Success("Wrote the access report to %s", path)
+	}
+	return nil
+}
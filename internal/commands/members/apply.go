@@ -0,0 +1,412 @@
+package members
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sumup/sumup-go/members"
+	"github.com/sumup/sumup-go/merchant"
+
+	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/commands/util"
+	"github.com/sumup/sumup-cli/internal/display"
+	"github.com/sumup/sumup-cli/internal/display/attribute"
+	"github.com/sumup/sumup-cli/internal/display/message"
+)
+
+// Actions of a roster plan.
+const (
+	planCreate = "create"
+	planInvite = "invite"
+	planUpdate = "update"
+	planRemove = "remove"
+)
+
+// roster is the file read by members apply.
+type roster struct {
+	Members []rosterEntry `yaml:"members"`
+}
+
+// planStep is a change needed to make the members of a merchant match the
+// roster.
+type planStep struct {
+	Action   string   `json:"action"`
+	Email    string   `json:"email"`
+	MemberID string   `json:"member_id,omitempty"`
+	Changes  []string `json:"changes,omitempty"`
+
+	entry rosterEntry
+	body  *members.UpdateMerchantMemberBody
+}
+
+// applyResult is the outcome of one plan step.
+type applyResult struct {
+	Action   string `json:"action"`
+	Email    string `json:"email"`
+	MemberID string `json:"member_id,omitempty"`
+	Password string `json:"password,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+func applyCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "apply",
+		Usage:  "Make the members of a merchant match a YAML roster.",
+		Action: applyRoster,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "merchant-code",
+				Usage:   "Merchant code whose members should match the roster. Falls back to context.",
+				Sources: cli.EnvVars("SUMUP_MERCHANT_CODE"),
+			},
+			&cli.StringFlag{
+				Name:     "file",
+				Aliases:  []string{"f"},
+				Usage:    "YAML roster listing the desired members.",
+				Required: true,
+			},
+			&cli.BoolFlag{
+				Name:  "prune",
+				Usage: "Remove members that are not listed in the roster.",
+			},
+			&cli.BoolFlag{
+				Name:  "plan-only",
+				Usage: "Only print the plan and exit with an error if the members differ from the roster.",
+			},
+		},
+	}
+}
+
+func applyRoster(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		return err
+	}
+
+	path := cmd.String("file")
+	entries, err := readRosterYAML(path)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("%s contains no members", path)
+	}
+	if err := validateRoster(entries, roleResolver(ctx, appCtx, merchantCode)); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	current, err := listAllMembers(ctx, appCtx, merchantCode)
+	if err != nil {
+		return err
+	}
+
+	// Pruning must not lock the user out of the merchant they manage.
+	var self string
+	if cmd.Bool("prune") {
+		self, err = accountEmail(ctx, appCtx)
+		if err != nil {
+			return fmt.Errorf("look up your membership before pruning: %w", err)
+		}
+	}
+
+	plan := planRoster(entries, current, cmd.Bool("prune"), self)
+	selfListed := slices.ContainsFunc(entries, func(entry rosterEntry) bool {
+		return strings.EqualFold(entry.Email, self)
+	})
+	selfMember := slices.ContainsFunc(current, func(member members.Member) bool {
+		return strings.EqualFold(memberEmail(member), self)
+	})
+	if self != "" && selfMember && !selfListed && !appCtx.JSONOutput {
+		message.Warn("Your own membership (%s) is not listed in the roster and is kept, run `sumup memberships leave %s` to remove it.", self, merchantCode)
+	}
+	planOnly := cmd.Bool("plan-only") || len(plan) == 0
+	switch {
+	case !appCtx.JSONOutput:
+		unlisted := 0
+		if !cmd.Bool("prune") {
+			unlisted = countAction(planRoster(entries, current, true, ""), planRemove)
+		}
+		renderPlan(plan, unlisted)
+	case planOnly:
+		if err := display.PrintJSON(plan); err != nil {
+			return err
+		}
+	}
+	if planOnly {
+		if len(plan) > 0 {
+			return fmt.Errorf("members of %s differ from %s in %d places", merchantCode, path, len(plan))
+		}
+		return nil
+	}
+
+	if appCtx.DryRun {
+		for _, step := range plan {
+			if err := dryRunStep(appCtx, merchantCode, step); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	err = util.Confirm(appCtx, util.Confirmation{
+		Action: "apply roster changes",
+		Name:   merchantCode,
+		Details: []attribute.KeyValue{
+			attribute.Attribute("Merchant Code", attribute.Styled(merchantCode)),
+			attribute.Attribute("Add", attribute.Styled(fmt.Sprint(countAction(plan, planCreate)+countAction(plan, planInvite)))),
+			attribute.Attribute("Update", attribute.Styled(fmt.Sprint(countAction(plan, planUpdate)))),
+			attribute.Attribute("Remove", attribute.Styled(fmt.Sprint(countAction(plan, planRemove)))),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	results := make([]applyResult, 0, len(plan))
+	failed := 0
+	for _, step := range plan {
+		result := applyStep(ctx, cmd, appCtx, merchantCode, step)
+		if result.Error != "" {
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	if appCtx.JSONOutput {
+		if err := display.PrintJSON(results); err != nil {
+			return err
+		}
+	} else {
+		renderApplyResults(results)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d changes failed", failed, len(results))
+	}
+	return nil
+}
+
+// planRoster compares the roster with the current members by email. Roles
+// are compared as sets, nicknames only when the roster sets one. Pruning
+// never removes the member with the email self.
+func planRoster(entries []rosterEntry, current []members.Member, prune bool, self string) []planStep {
+	byEmail := make(map[string]members.Member, len(current))
+	for _, member := range current {
+		byEmail[strings.ToLower(memberEmail(member))] = member
+	}
+
+	var plan []planStep
+	listed := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		key := strings.ToLower(entry.Email)
+		listed[key] = true
+
+		member, ok := byEmail[key]
+		if !ok {
+			step := planStep{Action: planInvite, Email: entry.Email, entry: entry}
+			if entry.Managed {
+				step.Action = planCreate
+			}
+			step.Changes = []string{"roles: " + memberRoles(entry.Roles)}
+			if entry.Nickname != "" {
+				step.Changes = append(step.Changes, "nickname: "+entry.Nickname)
+			}
+			plan = append(plan, step)
+			continue
+		}
+
+		body := members.UpdateMerchantMemberBody{}
+		var changes []string
+		if !sameRoles(member.Roles, entry.Roles) {
+			body.Roles = entry.Roles
+			changes = append(changes, fmt.Sprintf("roles: %s → %s", memberRoles(member.Roles), memberRoles(entry.Roles)))
+		}
+		// Nicknames belong to the user, pending invitations have none yet.
+		if entry.Nickname != "" && member.User != nil && memberNickname(member) != entry.Nickname {
+			nickname := entry.Nickname
+			body.User = &members.UpdateMerchantMemberBodyUser{Nickname: &nickname}
+			changes = append(changes, fmt.Sprintf("nickname: %s → %s", memberNickname(member), entry.Nickname))
+		}
+		if len(changes) > 0 {
+			plan = append(plan, planStep{Action: planUpdate, Email: entry.Email, MemberID: member.ID, Changes: changes, body: &body})
+		}
+	}
+
+	if prune {
+		for _, member := range current {
+			email := memberEmail(member)
+			if listed[strings.ToLower(email)] {
+				continue
+			}
+			if self != "" && strings.EqualFold(email, self) {
+				continue
+			}
+			plan = append(plan, planStep{
+				Action:   planRemove,
+				Email:    email,
+				MemberID: member.ID,
+				Changes:  []string{"roles: " + memberRoles(member.Roles)},
+			})
+		}
+	}
+	return plan
+}
+
+func applyStep(ctx context.Context, cmd *cli.Command, appCtx *app.Context, merchantCode string, step planStep) applyResult {
+	result := applyResult{Action: step.Action, Email: step.Email, MemberID: step.MemberID}
+	var err error
+	switch step.Action {
+	case planCreate, planInvite:
+		created := createRosterMember(ctx, cmd, appCtx, merchantCode, step.entry)
+		result.MemberID = created.MemberID
+		result.Password = created.Password
+		result.Error = created.Error
+		return result
+	case planUpdate:
+		_, err = appCtx.Client.Members.Update(ctx, merchantCode, step.MemberID, *step.body)
+		util.RecordAuditAction(cmd, "update", merchantCode, step.MemberID, err)
+	case planRemove:
+		err = appCtx.Client.Members.Delete(ctx, merchantCode, step.MemberID)
+		util.RecordAuditAction(cmd, "delete", merchantCode, step.MemberID, err)
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func dryRunStep(appCtx *app.Context, merchantCode string, step planStep) error {
+	switch step.Action {
+	case planUpdate:
+		return util.DryRun(appCtx, http.MethodPut, membersPath(merchantCode)+"/"+step.MemberID, step.body)
+	case planRemove:
+		return util.DryRun(appCtx, http.MethodDelete, membersPath(merchantCode)+"/"+step.MemberID, nil)
+	default:
+		body := createMemberBody(step.entry, nil)
+		if step.entry.Managed {
+			body.Password = maskedPassword()
+		}
+		return util.DryRun(appCtx, http.MethodPost, membersPath(merchantCode), body)
+	}
+}
+
+func renderPlan(plan []planStep, unlisted int) {
+	if len(plan) == 0 {
+		message.Success("Members match the roster, nothing to change.")
+	} else {
+		rows := make([][]string, 0, len(plan))
+		for _, step := range plan {
+			rows = append(rows, []string{step.Action, step.Email, dashIfEmpty(step.MemberID), strings.Join(step.Changes, "; ")})
+		}
+		display.RenderTable("Plan", []string{"Action", "Email", "Member ID", "Changes"}, rows)
+	}
+	if unlisted > 0 {
+		message.Notify("Members not listed in the roster: %d, pass --prune to remove them.", unlisted)
+	}
+}
+
+func renderApplyResults(results []applyResult) {
+	rows := make([][]string, 0, len(results))
+	passwords := false
+	for _, result := range results {
+		status := "done"
+		if result.Error != "" {
+			status = "failed"
+		}
+		passwords = passwords || result.Password != ""
+		rows = append(rows, []string{result.Action, result.Email, dashIfEmpty(result.MemberID), status, dashIfEmpty(result.Password), dashIfEmpty(result.Error)})
+	}
+	display.RenderTable("Applied changes", []string{"Action", "Email", "Member ID", "Status", "Password", "Error"}, rows)
+	if passwords {
+		message.Warn("Generated passwords are only shown once, hand them over to the members now.")
+	}
+}
+
+// readRosterYAML reads a roster like:
+//
+//	members:
+//	  - email: anna@example.com
+//	    roles: [role_employee]
+//	    nickname: Anna
+//	  - email: till1@example.com
+//	    roles: [role_employee]
+//	    managed: true
+func readRosterYAML(path string) ([]rosterEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read roster: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	var file roster
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	// Decode again as nodes to report problems with their line.
+	var nodes struct {
+		Members []yaml.Node `yaml:"members"`
+	}
+	if err := yaml.Unmarshal(data, &nodes); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	for i := range file.Members {
+		if i < len(nodes.Members) {
+			file.Members[i].Line = nodes.Members[i].Line
+		}
+	}
+	return file.Members, nil
+}
+
+func writeRosterYAML(w io.Writer, entries []rosterEntry) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(roster{Members: entries}); err != nil {
+		return fmt.Errorf("write roster: %w", err)
+	}
+	return encoder.Close()
+}
+
+// accountEmail returns the email the authenticated user signs in with.
+func accountEmail(ctx context.Context, appCtx *app.Context) (string, error) {
+	account, err := appCtx.Client.Merchant.Get(ctx, merchant.GetAccountParams{})
+	if err != nil {
+		return "", fmt.Errorf("get account: %w", err)
+	}
+	if account.Account == nil || account.Account.Username == nil {
+		return "", errors.New("get account: the API did not return your username")
+	}
+	return *account.Account.Username, nil
+}
+
+func sameRoles(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+func countAction(plan []planStep, action string) int {
+	count := 0
+	for _, step := range plan {
+		if step.Action == action {
+			count++
+		}
+	}
+	return count
+}
@@ -3,6 +3,7 @@ package members
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -13,7 +14,9 @@ import (
 	"github.com/sumup/sumup-go/shared"
 
 	"github.com/sumup/sumup-cli/internal/app"
+	rolescmd "github.com/sumup/sumup-cli/internal/commands/roles"
 	"github.com/sumup/sumup-cli/internal/commands/util"
+	"github.com/sumup/sumup-cli/internal/completion"
 	"github.com/sumup/sumup-cli/internal/display"
 	"github.com/sumup/sumup-cli/internal/display/attribute"
 	"github.com/sumup/sumup-cli/internal/display/message"
@@ -28,7 +31,7 @@ func NewCommand() *cli.Command {
 				Name:   "list",
 				Usage:  "List members attached to a merchant resource.",
 				Action: listMembers,
-				Flags: []cli.Flag{
+				Flags: append([]cli.Flag{
 					&cli.StringFlag{
 						Name:    "merchant-code",
 						Usage:   "Merchant code whose members should be listed. Falls back to context.",
@@ -62,13 +65,13 @@ func NewCommand() *cli.Command {
 						Name:  "scroll",
 						Usage: "Skip counting results to speed up pagination.",
 					},
-				},
+				}, util.FanOutFlags()...),
 			},
 			{
 				Name:   "create",
 				Usage:  "Create a merchant member.",
 				Action: createMember,
-				Flags: []cli.Flag{
+				Flags: append([]cli.Flag{
 					&cli.StringFlag{
 						Name:    "merchant-code",
 						Usage:   "Merchant code for the new member. Falls back to context.",
@@ -79,11 +82,6 @@ func NewCommand() *cli.Command {
 						Usage:    "Email for the new member.",
 						Required: true,
 					},
-					&cli.StringFlag{
-						Name:     "password",
-						Usage:    "Password for the managed user.",
-						Required: true,
-					},
 					&cli.StringSliceFlag{
 						Name:     "role",
 						Usage:    "Roles to assign to the member (repeat flag for multiple roles).",
@@ -93,7 +91,7 @@ func NewCommand() *cli.Command {
 						Name:  "nickname",
 						Usage: "Nickname for the member.",
 					},
-				},
+				}, passwordInput.Flags()...),
 			},
 			{
 				Name:   "invite",
@@ -110,13 +108,78 @@ func NewCommand() *cli.Command {
 						Usage:    "Email of the user to invite.",
 						Required: true,
 					},
+					&cli.StringSliceFlag{
+						Name:  "role",
+						Usage: "Roles to assign to the member (repeat flag for multiple roles).",
+						Value: []string{"role_employee"},
+					},
+					&cli.StringFlag{
+						Name:  "nickname",
+						Usage: "Nickname for the member.",
+					},
+				},
+			},
+			{
+				Name:          "get",
+				Usage:         "Get a member with their user, invite state and roles.",
+				Action:        getMember,
+				ArgsUsage:     "<member-id>",
+				ShellComplete: completion.Complete(completion.Members, nil),
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "merchant-code",
+						Usage:   "Merchant code the member belongs to. Falls back to context.",
+						Sources: cli.EnvVars("SUMUP_MERCHANT_CODE"),
+					},
 				},
 			},
 			{
-				Name:      "delete",
-				Usage:     "Delete a member from the merchant account.",
-				Action:    deleteMember,
-				ArgsUsage: "<member-id>",
+				Name:          "update",
+				Usage:         "Update the roles or nickname of a member.",
+				Action:        updateMember,
+				ArgsUsage:     "<member-id>",
+				ShellComplete: completion.Complete(completion.Members, nil),
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "merchant-code",
+						Usage:   "Merchant code the member belongs to. Falls back to context.",
+						Sources: cli.EnvVars("SUMUP_MERCHANT_CODE"),
+					},
+					&cli.StringSliceFlag{
+						Name:  "role",
+						Usage: "Roles of the member, replacing the current ones (repeat flag for multiple roles).",
+					},
+					&cli.StringFlag{
+						Name:  "nickname",
+						Usage: "New nickname for the member.",
+					},
+				},
+			},
+			{
+				Name:          "resend-invite",
+				Usage:         "Send a new invitation to a member whose invite is pending or expired. The member is invited again under a new ID.",
+				Action:        resendInvite,
+				ArgsUsage:     "<member-id>",
+				ShellComplete: completion.Complete(completion.Members, nil),
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "merchant-code",
+						Usage:   "Merchant code the member belongs to. Falls back to context.",
+						Sources: cli.EnvVars("SUMUP_MERCHANT_CODE"),
+					},
+				},
+			},
+			importCommand(),
+			exportCommand(),
+			applyCommand(),
+			offboardCommand(),
+			accessReportCommand(),
+			{
+				Name:          "delete",
+				Usage:         "Delete a member from the merchant account.",
+				Action:        deleteMember,
+				ArgsUsage:     "<member-id>",
+				ShellComplete: completion.Complete(completion.Members, nil),
 				Flags: []cli.Flag{
 					&cli.StringFlag{
 						Name:    "merchant-code",
@@ -135,11 +198,6 @@ func listMembers(ctx context.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
-	if err != nil {
-		return err
-	}
-
 	params := members.ListMerchantMembersParams{}
 	if cmd.IsSet("offset") {
 		value := cmd.Int("offset")
@@ -170,6 +228,19 @@ func listMembers(ctx context.Context, cmd *cli.Command) error {
 		params.Status = &status
 	}
 
+	merchants, fanOut, err := util.FanOutMerchants(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	if fanOut {
+		return listMerchantsMembers(ctx, cmd, appCtx, merchants, params)
+	}
+
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		return err
+	}
+
 	response, err := appCtx.Client.Members.List(ctx, merchantCode, params)
 	if err != nil {
 		return fmt.Errorf("list members: %w", err)
@@ -181,19 +252,54 @@ func listMembers(ctx context.Context, cmd *cli.Command) error {
 
 	rows := make([][]string, 0, len(response.Items))
 	for _, member := range response.Items {
-		rows = append(rows, []string{
-			member.ID,
-			memberEmail(member),
-			memberRoles(member.Roles),
-			membershipStatusLabel(member.Status),
-			member.CreatedAt.UTC().Format(time.RFC3339),
-		})
+		rows = append(rows, memberRow(member))
 	}
 
-	display.RenderTable("Members", []string{"ID", "Email", "Roles", "Status", "Created At"}, rows)
+	display.RenderTable("Members", memberHeaders, rows)
 	return nil
 }
 
+var memberHeaders = []string{"ID", "Email", "Roles", "Status", "Created At"}
+
+// listMerchantsMembers lists the members of many merchants with the same
+// filters.
+func listMerchantsMembers(ctx context.Context, cmd *cli.Command, appCtx *app.Context, merchants []util.Merchant, params members.ListMerchantMembersParams) error {
+	results := util.FanOut(ctx, cmd, merchants, func(ctx context.Context, merchantCode string) ([]members.Member, error) {
+		response, err := appCtx.Client.Members.List(ctx, merchantCode, params)
+		if err != nil {
+			return nil, fmt.Errorf("list members: %w", err)
+		}
+		return response.Items, nil
+	})
+
+	if appCtx.JSONOutput {
+		if err := display.PrintJSON(results); err != nil {
+			return err
+		}
+		return util.FanOutErrors(appCtx, results)
+	}
+
+	var rows [][]string
+	for _, result := range results {
+		for _, member := range result.Result {
+			rows = append(rows, append([]string{result.MerchantCode}, memberRow(member)...))
+		}
+	}
+
+	display.RenderTable("Members", append([]string{"Merchant"}, memberHeaders...), rows)
+	return util.FanOutErrors(appCtx, results)
+}
+
+func memberRow(member members.Member) []string {
+	return []string{
+		member.ID,
+		memberEmail(member),
+		memberRoles(member.Roles),
+		membershipStatusLabel(member.Status),
+		member.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
 func createMember(ctx context.Context, cmd *cli.Command) error {
 	appCtx, err := app.GetAppContext(cmd)
 	if err != nil {
@@ -209,9 +315,17 @@ func createMember(ctx context.Context, cmd *cli.Command) error {
 	if len(roles) == 0 {
 		return fmt.Errorf("at least one --role is required")
 	}
+	roles, err = resolveRoles(ctx, appCtx, merchantCode, roles)
+	if err != nil {
+		return err
+	}
+
+	password, generated, err := passwordInput.Read(cmd)
+	if err != nil {
+		return err
+	}
 
 	isManaged := true
-	password := secret.New(cmd.String("password"))
 
 	body := members.CreateMerchantMemberBody{
 		Email:         cmd.String("email"),
@@ -223,22 +337,60 @@ func createMember(ctx context.Context, cmd *cli.Command) error {
 		body.Nickname = &nickname
 	}
 
+	if appCtx.DryRun {
+		masked := body
+		masked.Password = maskedPassword()
+		return util.DryRun(appCtx, http.MethodPost, membersPath(merchantCode), masked)
+	}
+
 	response, err := appCtx.Client.Members.Create(ctx, merchantCode, body)
 	if err != nil {
+		util.RecordAudit(cmd, merchantCode, "", err)
 		return fmt.Errorf("create member: %w", err)
 	}
+	util.RecordAudit(cmd, merchantCode, response.ID, nil)
 
 	if appCtx.JSONOutput {
+		if generated {
+			return display.PrintJSON(createdMember{Member: response, Password: password.Value()})
+		}
 		return display.PrintJSON(response)
 	}
 
 	message.Success("Member created")
-	display.DataList([]attribute.KeyValue{
+	details := []attribute.KeyValue{
 		attribute.ID(response.ID),
-	})
+	}
+	if generated {
+		details = append(details, attribute.Attribute("Password", attribute.Styled(password.Value())))
+	}
+	display.DataList(details)
+	if generated {
+		message.Warn("The generated password is only shown once, hand it over to the member now.")
+	}
 	return nil
 }
 
+// maskedPassword stands in for passwords in dry-run output.
+func maskedPassword() *secret.Secret {
+	hidden := secret.New("***")
+	return &hidden
+}
+
+// createdMember is the JSON output of members create with a generated
+// password.
+type createdMember struct {
+	*members.Member
+	Password string `json:"password"`
+}
+
+// passwordInput reads the password of managed users.
+var passwordInput = util.SecretInput{
+	Name:     "password",
+	Label:    "Password",
+	Generate: true,
+}
+
 func inviteMember(ctx context.Context, cmd *cli.Command) error {
 	appCtx, err := app.GetAppContext(cmd)
 	if err != nil {
@@ -250,15 +402,29 @@ func inviteMember(ctx context.Context, cmd *cli.Command) error {
 		return err
 	}
 
+	roles, err := resolveRoles(ctx, appCtx, merchantCode, cmd.StringSlice("role"))
+	if err != nil {
+		return err
+	}
+
 	body := members.CreateMerchantMemberBody{
 		Email: cmd.String("email"),
-		Roles: []string{"role_employee"},
+		Roles: roles,
+	}
+	if nickname := cmd.String("nickname"); nickname != "" {
+		body.Nickname = &nickname
+	}
+
+	if appCtx.DryRun {
+		return util.DryRun(appCtx, http.MethodPost, membersPath(merchantCode), body)
 	}
 
 	response, err := appCtx.Client.Members.Create(ctx, merchantCode, body)
 	if err != nil {
+		util.RecordAudit(cmd, merchantCode, "", err)
 		return fmt.Errorf("invite member: %w", err)
 	}
+	util.RecordAudit(cmd, merchantCode, response.ID, nil)
 
 	if appCtx.JSONOutput {
 		return display.PrintJSON(response)
@@ -271,6 +437,177 @@ func inviteMember(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
+func getMember(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		return err
+	}
+
+	memberID, err := util.RequireSingleArg(cmd, "member ID")
+	if err != nil {
+		return err
+	}
+
+	member, err := appCtx.Client.Members.Get(ctx, merchantCode, memberID)
+	if err != nil {
+		return fmt.Errorf("retrieve member: %w", err)
+	}
+
+	if appCtx.JSONOutput {
+		return display.PrintJSON(member)
+	}
+
+	renderMember(appCtx, member)
+	return nil
+}
+
+func updateMember(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		return err
+	}
+
+	memberID, err := util.RequireSingleArg(cmd, "member ID")
+	if err != nil {
+		return err
+	}
+
+	body := members.UpdateMerchantMemberBody{}
+	if cmd.IsSet("role") {
+		roles, err := resolveRoles(ctx, appCtx, merchantCode, cmd.StringSlice("role"))
+		if err != nil {
+			return err
+		}
+		body.Roles = roles
+	}
+	if cmd.IsSet("nickname") {
+		nickname := cmd.String("nickname")
+		body.User = &members.UpdateMerchantMemberBodyUser{Nickname: &nickname}
+	}
+	if body.Roles == nil && body.User == nil {
+		return fmt.Errorf("nothing to update, pass --role or --nickname")
+	}
+
+	if appCtx.DryRun {
+		return util.DryRun(appCtx, http.MethodPut, membersPath(merchantCode)+"/"+memberID, body)
+	}
+
+	member, err := appCtx.Client.Members.Update(ctx, merchantCode, memberID, body)
+	util.RecordAudit(cmd, merchantCode, memberID, err)
+	if err != nil {
+		return fmt.Errorf("update member: %w", err)
+	}
+
+	if appCtx.JSONOutput {
+		return display.PrintJSON(member)
+	}
+
+	message.Success("Member updated")
+	renderMember(appCtx, member)
+	return nil
+}
+
+// resendInvite replaces the invitation of a member that has not accepted it
+// yet. The API cannot resend invitations, so the member is removed and
+// invited again with the same email, roles and nickname, which gives the
+// member a new ID.
+func resendInvite(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		return err
+	}
+
+	memberID, err := util.RequireSingleArg(cmd, "member ID")
+	if err != nil {
+		return err
+	}
+
+	member, err := appCtx.Client.Members.Get(ctx, merchantCode, memberID)
+	if err != nil {
+		return fmt.Errorf("retrieve member: %w", err)
+	}
+	if member.Invite == nil || (member.Status != shared.MembershipStatusPending && member.Status != shared.MembershipStatusExpired) {
+		return fmt.Errorf("member %s has no pending or expired invitation", member.ID)
+	}
+
+	body := members.CreateMerchantMemberBody{
+		Email: member.Invite.Email,
+		Roles: member.Roles,
+	}
+	if member.User != nil {
+		body.Nickname = member.User.Nickname
+	}
+
+	if appCtx.DryRun {
+		if err := util.DryRun(appCtx, http.MethodDelete, membersPath(merchantCode)+"/"+member.ID, nil); err != nil {
+			return err
+		}
+		return util.DryRun(appCtx, http.MethodPost, membersPath(merchantCode), body)
+	}
+
+	err = util.Confirm(appCtx, util.Confirmation{
+		Action: "replace the invitation of member",
+		Name:   body.Email,
+		Details: []attribute.KeyValue{
+			attribute.ID(member.ID),
+			attribute.Attribute("Email", attribute.Styled(body.Email)),
+			attribute.Attribute("Roles", attribute.Styled(memberRoles(body.Roles))),
+			attribute.Attribute("Status", attribute.Styled(membershipStatusLabel(member.Status))),
+			attribute.Attribute("Note", attribute.Styled("The member is deleted and invited again under a new ID")),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = appCtx.Client.Members.Delete(ctx, merchantCode, member.ID)
+	util.RecordAuditAction(cmd, "delete", merchantCode, member.ID, err)
+	if err != nil {
+		return fmt.Errorf("delete expired invitation: %w", err)
+	}
+
+	response, err := appCtx.Client.Members.Create(ctx, merchantCode, body)
+	if err != nil {
+		util.RecordAudit(cmd, merchantCode, "", err)
+		// The member is gone at this point, so show what is needed to
+		// invite them again by hand.
+		message.Error("Member %s was deleted but could not be invited again. Invite them with:", member.ID)
+		display.DataList([]attribute.KeyValue{
+			attribute.Attribute("Email", attribute.Styled(body.Email)),
+			attribute.Attribute("Roles", attribute.Styled(memberRoles(body.Roles))),
+			attribute.Attribute("Nickname", attribute.Styled(util.StringOrDefault(body.Nickname, "-"))),
+		})
+		return fmt.Errorf("invite member: %w", err)
+	}
+	util.RecordAudit(cmd, merchantCode, response.ID, nil)
+
+	if appCtx.JSONOutput {
+		return display.PrintJSON(response)
+	}
+
+	message.Success("Invitation sent to %s, the member now has a new ID", body.Email)
+	display.DataList([]attribute.KeyValue{
+		attribute.ID(response.ID),
+		attribute.Attribute("Previous ID", attribute.Styled(member.ID)),
+	})
+	return nil
+}
+
 func deleteMember(ctx context.Context, cmd *cli.Command) error {
 	appCtx, err := app.GetAppContext(cmd)
 	if err != nil {
@@ -286,7 +623,32 @@ func deleteMember(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	if err := appCtx.Client.Members.Delete(ctx, merchantCode, memberID); err != nil {
+
+	if appCtx.DryRun {
+		return util.DryRun(appCtx, http.MethodDelete, membersPath(merchantCode)+"/"+memberID, nil)
+	}
+
+	member, err := appCtx.Client.Members.Get(ctx, merchantCode, memberID)
+	if err != nil {
+		return fmt.Errorf("retrieve member: %w", err)
+	}
+	err = util.Confirm(appCtx, util.Confirmation{
+		Action: "delete member",
+		Name:   memberEmail(*member),
+		Details: []attribute.KeyValue{
+			attribute.ID(member.ID),
+			attribute.Attribute("Email", attribute.Styled(memberEmail(*member))),
+			attribute.Attribute("Roles", attribute.Styled(memberRoles(member.Roles))),
+			attribute.Attribute("Status", attribute.Styled(membershipStatusLabel(member.Status))),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = appCtx.Client.Members.Delete(ctx, merchantCode, memberID)
+	util.RecordAudit(cmd, merchantCode, memberID, err)
+	if err != nil {
 		return fmt.Errorf("delete member: %w", err)
 	}
 
@@ -294,6 +656,91 @@ func deleteMember(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
+// resolveRoles validates the roles against the roles of the merchant and maps
+// role names to IDs.
+func resolveRoles(ctx context.Context, appCtx *app.Context, merchantCode string, values []string) ([]string, error) {
+	return roleResolver(ctx, appCtx, merchantCode)(values)
+}
+
+// roleResolver lists the roles of the merchant once and returns a function
+// validating roles against them. The roles are passed on unchecked when they
+// cannot be listed, the API then rejects unknown ones, and so are the usual
+// predefined roles when the API lists none.
+func roleResolver(ctx context.Context, appCtx *app.Context, merchantCode string) func(values []string) ([]string, error) {
+	response, err := appCtx.Client.Roles.List(ctx, merchantCode)
+	if err != nil {
+		if !appCtx.JSONOutput {
+			message.Warn("Could not list roles to validate them: %v", err)
+		}
+		return func(values []string) ([]string, error) {
+			return values, nil
+		}
+	}
+	warned := map[string]bool{}
+	return func(values []string) ([]string, error) {
+		ids, unconfirmed, err := rolescmd.Resolve(response.Items, values)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range unconfirmed {
+			if !warned[id] && !appCtx.JSONOutput {
+				message.Warn("The API listed no predefined roles for %s, passing %s on unchecked.", merchantCode, id)
+			}
+			warned[id] = true
+		}
+		return ids, nil
+	}
+}
+
+func renderMember(appCtx *app.Context, member *members.Member) {
+	details := []attribute.KeyValue{
+		attribute.ID(member.ID),
+		attribute.Attribute("Email", attribute.Styled(memberEmail(*member))),
+		attribute.Attribute("Nickname", attribute.Styled(memberNickname(*member))),
+		attribute.Attribute("Status", attribute.Styled(membershipStatusLabel(member.Status))),
+		attribute.Attribute("Roles", attribute.Styled(memberRoles(member.Roles))),
+		attribute.Attribute("Permissions", attribute.Styled(memberRoles(member.Permissions))),
+	}
+	if user := member.User; user != nil {
+		details = append(details,
+			attribute.Attribute("User ID", attribute.Styled(user.ID)),
+			attribute.Attribute("MFA on Login", attribute.Styled(util.BoolLabel(&user.MfaOnLoginEnabled))),
+		)
+		if user.ServiceAccountUser {
+			details = append(details, attribute.Attribute("Service Account", attribute.Styled("Yes")))
+		}
+		if user.DisabledAt != nil {
+			details = append(details, attribute.Attribute("Disabled At", attribute.Styled(util.TimeOrDash(appCtx, user.DisabledAt))))
+		}
+	}
+	if invite := member.Invite; invite != nil {
+		state := "Pending"
+		if member.Status == shared.MembershipStatusExpired || invite.ExpiresAt.Before(time.Now()) {
+			state = "Expired, use resend-invite to send a new one"
+		}
+		details = append(details,
+			attribute.Attribute("Invite", attribute.Styled(state)),
+			attribute.Attribute("Invite Expires At", attribute.Styled(util.TimeOrDash(appCtx, &invite.ExpiresAt))),
+		)
+	}
+	details = append(details,
+		attribute.Attribute("Created At", attribute.Styled(util.TimeOrDash(appCtx, &member.CreatedAt))),
+		attribute.Attribute("Updated At", attribute.Styled(util.TimeOrDash(appCtx, &member.UpdatedAt))),
+	)
+	display.DataList(details)
+}
+
+func memberNickname(member members.Member) string {
+	if member.User != nil && member.User.Nickname != nil && *member.User.Nickname != "" {
+		return *member.User.Nickname
+	}
+	return "-"
+}
+
+func membersPath(merchantCode string) string {
+	return fmt.Sprintf("/v0.1/merchants/%s/members", merchantCode)
+}
+
 func parseMembershipStatus(value string) (shared.MembershipStatus, error) {
 	switch strings.ToLower(value) {
 	case "accepted":
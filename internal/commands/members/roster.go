@@ -0,0 +1,607 @@
+package members
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/urfave/cli/v3"
+	"golang.org/x/term"
+
+	"github.com/sumup/sumup-go/members"
+	"github.com/sumup/sumup-go/secret"
+
+	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/commands/util"
+	"github.com/sumup/sumup-cli/internal/display"
+	"github.com/sumup/sumup-cli/internal/display/message"
+)
+
+const (
+	// membersPageSize is the number of members requested per page when
+	// loading the whole roster.
+	membersPageSize = 100
+	// defaultImportConcurrency is the number of members created at the same
+	// time by members import.
+	defaultImportConcurrency = 5
+)
+
+// Member types of a roster. Managed users are created with a password by the
+// merchant, everyone else is invited by email.
+const (
+	memberTypeInvite  = "invite"
+	memberTypeManaged = "managed"
+)
+
+// rosterColumns are the columns of roster CSV files, in export order.
+var rosterColumns = []string{"email", "roles", "nickname", "type"}
+
+// rosterEntry is a member as listed in a roster file.
+type rosterEntry struct {
+	Email    string   `json:"email" yaml:"email"`
+	Roles    []string `json:"roles" yaml:"roles"`
+	Nickname string   `json:"nickname,omitempty" yaml:"nickname,omitempty"`
+	Managed  bool     `json:"managed,omitempty" yaml:"managed,omitempty"`
+	// Line is the line of the entry in the file, for error messages.
+	Line int `json:"-" yaml:"-"`
+}
+
+// importResult is the outcome of importing one roster entry.
+type importResult struct {
+	Line     int    `json:"line"`
+	Email    string `json:"email"`
+	Status   string `json:"status"`
+	MemberID string `json:"member_id,omitempty"`
+	Password string `json:"password,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+func importCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "import",
+		Usage:     "Create members from a CSV file with email, roles, nickname and type columns.",
+		ArgsUsage: "<file.csv>",
+		Action:    importMembers,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "merchant-code",
+				Usage:   "Merchant code to add the members to. Falls back to context.",
+				Sources: cli.EnvVars("SUMUP_MERCHANT_CODE"),
+			},
+			&cli.StringFlag{
+				Name:  "report",
+				Usage: "Write the result of every row to this CSV file, including generated passwords.",
+			},
+			&cli.IntFlag{
+				Name:  "concurrency",
+				Usage: "Maximum number of members created at the same time.",
+				Value: defaultImportConcurrency,
+			},
+		},
+	}
+}
+
+func exportCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "export",
+		Usage:  "Write the members of a merchant as a roster for members import or members apply.",
+		Action: exportMembers,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "merchant-code",
+				Usage:   "Merchant code whose members should be exported. Falls back to context.",
+				Sources: cli.EnvVars("SUMUP_MERCHANT_CODE"),
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Write the roster to this file instead of standard output.",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "Roster format: csv for members import or yaml for members apply.",
+				Value: "csv",
+			},
+		},
+	}
+}
+
+func importMembers(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		return err
+	}
+
+	path, err := util.RequireSingleArg(cmd, "CSV file")
+	if err != nil {
+		return err
+	}
+
+	entries, err := readRosterFile(path)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("%s contains no members", path)
+	}
+
+	current, err := listAllMembers(ctx, appCtx, merchantCode)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]members.Member, len(current))
+	for _, member := range current {
+		existing[strings.ToLower(memberEmail(member))] = member
+	}
+
+	if err := validateRoster(entries, roleResolver(ctx, appCtx, merchantCode)); err != nil {
+		return fmt.Errorf("%w\nnothing was imported", err)
+	}
+
+	results := make([]importResult, len(entries))
+	var pending []int
+	for i, entry := range entries {
+		results[i] = importResult{Line: entry.Line, Email: entry.Email}
+		if member, ok := existing[strings.ToLower(entry.Email)]; ok {
+			results[i].Status = "skipped"
+			results[i].MemberID = member.ID
+			results[i].Error = "already a member"
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	if appCtx.DryRun {
+		for _, i := range pending {
+			body := createMemberBody(entries[i], nil)
+			if entries[i].Managed {
+				body.Password = maskedPassword()
+			}
+			if err := util.DryRun(appCtx, http.MethodPost, membersPath(merchantCode), body); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// The report holds the only copy of the generated passwords, so make
+	// sure it can be written before creating anyone.
+	var reportFile *os.File
+	if report := cmd.String("report"); report != "" {
+		reportFile, err = os.OpenFile(report, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return fmt.Errorf("create report: %w", err)
+		}
+		defer reportFile.Close()
+	}
+
+	progress := newProgress("Importing members", len(pending))
+	semaphore := make(chan struct{}, max(1, cmd.Int("concurrency")))
+	var wg sync.WaitGroup
+	for _, i := range pending {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			defer progress.Add()
+
+			results[i] = createRosterMember(ctx, cmd, appCtx, merchantCode, entries[i])
+		}()
+	}
+	wg.Wait()
+	progress.Done()
+
+	var reportErr error
+	if reportFile != nil {
+		reportErr = writeImportReport(reportFile, results)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Status == "failed" {
+			failed++
+		}
+	}
+
+	if appCtx.JSONOutput {
+		if err := display.PrintJSON(results); err != nil {
+			return err
+		}
+	} else {
+		// Show the passwords when the report could not be written, they
+		// would be lost otherwise.
+		renderImportResults(results, reportFile == nil || reportErr != nil)
+		if reportFile != nil && reportErr == nil {
+			message.Notify("Wrote the results to %s", reportFile.Name())
+		}
+	}
+	if reportErr != nil {
+		return reportErr
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d rows failed", failed, len(results))
+	}
+	return nil
+}
+
+// createRosterMember creates the member of a roster entry, generating a
+// password for managed users.
+func createRosterMember(ctx context.Context, cmd *cli.Command, appCtx *app.Context, merchantCode string, entry rosterEntry) importResult {
+	result := importResult{Line: entry.Line, Email: entry.Email}
+
+	var password *secret.Secret
+	if entry.Managed {
+		value, err := util.GenerateSecret()
+		if err != nil {
+			result.Status = "failed"
+			result.Error = err.Error()
+			return result
+		}
+		generated := secret.New(value)
+		password = &generated
+	}
+
+	response, err := appCtx.Client.Members.Create(ctx, merchantCode, createMemberBody(entry, password))
+	if err != nil {
+		util.RecordAudit(cmd, merchantCode, "", err)
+		result.Status = "failed"
+		result.Error = err.Error()
+		return result
+	}
+	util.RecordAudit(cmd, merchantCode, response.ID, nil)
+
+	result.MemberID = response.ID
+	result.Status = "invited"
+	if password != nil {
+		result.Status = "created"
+		result.Password = password.Value()
+	}
+	return result
+}
+
+func createMemberBody(entry rosterEntry, password *secret.Secret) members.CreateMerchantMemberBody {
+	body := members.CreateMerchantMemberBody{
+		Email: entry.Email,
+		Roles: entry.Roles,
+	}
+	if entry.Nickname != "" {
+		nickname := entry.Nickname
+		body.Nickname = &nickname
+	}
+	if entry.Managed {
+		managed := true
+		body.IsManagedUser = &managed
+		body.Password = password
+	}
+	return body
+}
+
+func exportMembers(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		return err
+	}
+
+	current, err := listAllMembers(ctx, appCtx, merchantCode)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]rosterEntry, 0, len(current))
+	for _, member := range current {
+		entries = append(entries, rosterEntryOf(member))
+	}
+
+	if appCtx.JSONOutput {
+		return display.PrintJSON(entries)
+	}
+
+	var write func(io.Writer, []rosterEntry) error
+	switch format := cmd.String("format"); format {
+	case "csv":
+		write = writeRosterCSV
+	case "yaml":
+		write = writeRosterYAML
+	default:
+		return fmt.Errorf("unsupported format %q, expected csv or yaml", format)
+	}
+
+	output := cmd.String("output")
+	if output == "" {
+		return write(os.Stdout, entries)
+	}
+	file, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", output, err)
+	}
+	if err := write(file, entries); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("write %s: %w", output, err)
+	}
+	message.Success("Exported %d members to %s", len(entries), output)
+	return nil
+}
+
+// listAllMembers returns all members of the merchant, following pagination.
+func listAllMembers(ctx context.Context, appCtx *app.Context, merchantCode string) ([]members.Member, error) {
+	var items []members.Member
+	limit := membersPageSize
+	for offset := 0; ; offset += membersPageSize {
+		response, err := appCtx.Client.Members.List(ctx, merchantCode, members.ListMerchantMembersParams{
+			Limit:  &limit,
+			Offset: &offset,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list members: %w", err)
+		}
+		items = append(items, response.Items...)
+		if len(response.Items) < membersPageSize || (response.TotalCount != nil && *response.TotalCount > 0 && len(items) >= *response.TotalCount) {
+			return items, nil
+		}
+	}
+}
+
+// rosterEntryOf describes an existing member as a roster entry. Members
+// backed by a virtual user were created as managed users by the merchant.
+func rosterEntryOf(member members.Member) rosterEntry {
+	entry := rosterEntry{
+		Email: memberEmail(member),
+		Roles: member.Roles,
+	}
+	if member.User != nil {
+		entry.Managed = member.User.VirtualUser
+		if member.User.Nickname != nil {
+			entry.Nickname = *member.User.Nickname
+		}
+	}
+	return entry
+}
+
+// validateRoster checks every entry and resolves role names to IDs, so that
+// a file with mistakes is rejected before any member is created.
+func validateRoster(entries []rosterEntry, resolve func([]string) ([]string, error)) error {
+	var problems []string
+	seen := map[string]int{}
+	for i := range entries {
+		entry := &entries[i]
+		fail := func(format string, args ...any) {
+			problems = append(problems, fmt.Sprintf("line %d: %s", entry.Line, fmt.Sprintf(format, args...)))
+		}
+
+		address, err := mail.ParseAddress(entry.Email)
+		if err != nil || address.Address != entry.Email {
+			fail("invalid email %q", entry.Email)
+		}
+		key := strings.ToLower(entry.Email)
+		if line, ok := seen[key]; ok {
+			fail("%s is already listed on line %d", entry.Email, line)
+		}
+		seen[key] = entry.Line
+
+		if len(entry.Roles) == 0 {
+			fail("no roles for %s", entry.Email)
+			continue
+		}
+		roles, err := resolve(entry.Roles)
+		if err != nil {
+			fail("%v", err)
+			continue
+		}
+		entry.Roles = roles
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid rows:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// readRosterFile reads a roster CSV file, or standard input for "-".
+func readRosterFile(path string) ([]rosterEntry, error) {
+	if path == "-" {
+		return readRosterCSV(os.Stdin)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open roster: %w", err)
+	}
+	defer file.Close()
+	return readRosterCSV(file)
+}
+
+// readRosterCSV parses a roster with a header row naming the columns. Roles
+// within a cell are separated by semicolons, the type is "invite" (default)
+// or "managed".
+func readRosterCSV(r io.Reader) ([]rosterEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read roster header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !slices.Contains(rosterColumns, name) {
+			return nil, fmt.Errorf("unknown roster column %q, expected %s", name, strings.Join(rosterColumns, ", "))
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["email"]; !ok {
+		return nil, errors.New("roster has no email column")
+	}
+
+	var entries []rosterEntry
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read roster: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		cell := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if strings.Join(record, "") == "" {
+			continue
+		}
+
+		entry := rosterEntry{
+			Email:    cell("email"),
+			Nickname: cell("nickname"),
+			Line:     line,
+		}
+		for _, role := range strings.Split(cell("roles"), ";") {
+			if role = strings.TrimSpace(role); role != "" {
+				entry.Roles = append(entry.Roles, role)
+			}
+		}
+		switch strings.ToLower(cell("type")) {
+		case "", memberTypeInvite:
+		case memberTypeManaged:
+			entry.Managed = true
+		default:
+			return nil, fmt.Errorf("line %d: unknown type %q, expected %s or %s", line, cell("type"), memberTypeInvite, memberTypeManaged)
+		}
+		entries = append(entries, entry)
+	}
+}
+
+func writeRosterCSV(w io.Writer, entries []rosterEntry) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(rosterColumns); err != nil {
+		return fmt.Errorf("write roster: %w", err)
+	}
+	for _, entry := range entries {
+		kind := memberTypeInvite
+		if entry.Managed {
+			kind = memberTypeManaged
+		}
+		if err := writer.Write([]string{entry.Email, strings.Join(entry.Roles, ";"), entry.Nickname, kind}); err != nil {
+			return fmt.Errorf("write roster: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("write roster: %w", err)
+	}
+	return nil
+}
+
+// writeImportReport writes the import results as CSV and closes the file.
+func writeImportReport(file *os.File, results []importResult) error {
+	writer := csv.NewWriter(file)
+	_ = writer.Write([]string{"line", "email", "status", "member_id", "password", "error"})
+	for _, result := range results {
+		_ = writer.Write([]string{strconv.Itoa(result.Line), result.Email, result.Status, result.MemberID, result.Password, result.Error})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	return nil
+}
+
+func renderImportResults(results []importResult, showPasswords bool) {
+	headers := []string{"Line", "Email", "Status", "Member ID", "Error"}
+	if showPasswords {
+		headers = []string{"Line", "Email", "Status", "Member ID", "Password", "Error"}
+	}
+
+	passwords := false
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		row := []string{strconv.Itoa(result.Line), result.Email, result.Status, dashIfEmpty(result.MemberID)}
+		if showPasswords {
+			row = append(row, dashIfEmpty(result.Password))
+			passwords = passwords || result.Password != ""
+		}
+		rows = append(rows, append(row, dashIfEmpty(result.Error)))
+	}
+	display.RenderTable("Import results", headers, rows)
+	if passwords {
+		message.Warn("Generated passwords are only shown once, hand them over to the members now.")
+	}
+}
+
+func dashIfEmpty(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// progress reports how many of a known number of operations have finished,
+// on a single line of stderr. It stays silent when stderr is not a terminal.
+type progress struct {
+	mu      sync.Mutex
+	label   string
+	total   int
+	done    int
+	enabled bool
+}
+
+func newProgress(label string, total int) *progress {
+	p := &progress{
+		label:   label,
+		total:   total,
+		enabled: total > 0 && term.IsTerminal(int(os.Stderr.Fd())),
+	}
+	p.print()
+	return p
+}
+
+// Add records a finished operation.
+func (p *progress) Add() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done++
+	p.print()
+}
+
+// Done ends the progress line.
+func (p *progress) Done() {
+	if p.enabled {
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+func (p *progress) print() {
+	if p.enabled {
+		fmt.Fprintf(os.Stderr, "\r%s %d/%d", p.label, p.done, p.total)
+	}
+}
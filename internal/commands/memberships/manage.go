@@ -0,0 +1,199 @@
+package memberships
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/sumup/sumup-go/members"
+	"github.com/sumup/sumup-go/memberships"
+	"github.com/sumup/sumup-go/merchant"
+	"github.com/sumup/sumup-go/shared"
+
+	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/commands/util"
+	"github.com/sumup/sumup-cli/internal/display"
+	"github.com/sumup/sumup-cli/internal/display/attribute"
+	"github.com/sumup/sumup-cli/internal/display/message"
+	"github.com/sumup/sumup-cli/internal/membership"
+)
+
+// pendingPageSize is the number of invitations listed by memberships pending.
+const pendingPageSize = 100
+
+func listPending(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	status := shared.MembershipStatusPending
+	limit := pendingPageSize
+	response, err := appCtx.Client.Memberships.List(ctx, memberships.ListMembershipsParams{
+		Status: &status,
+		Limit:  &limit,
+	})
+	if err != nil {
+		return fmt.Errorf("list memberships: %w", err)
+	}
+
+	if appCtx.JSONOutput {
+		return display.PrintJSON(response.Items)
+	}
+
+	if len(response.Items) == 0 {
+		message.Notify("You have no pending invitations.")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(response.Items))
+	for _, item := range response.Items {
+		email, expires := "-", "-"
+		if item.Invite != nil {
+			email = item.Invite.Email
+			expires = util.TimeOrDash(appCtx, &item.Invite.ExpiresAt)
+		}
+		rows = append(rows, []string{
+			item.ID,
+			item.Resource.Name,
+			string(item.Resource.Type),
+			memberRoles(item.Roles),
+			email,
+			expires,
+		})
+	}
+
+	display.RenderTable("Pending invitations", []string{"ID", "Resource", "Type", "Roles", "Invited Email", "Expires"}, rows)
+	message.Notify("Accept or decline invitations with the link in the invitation email, the API cannot act on them.")
+	return nil
+}
+
+func membershipTree(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	nodes, err := membership.Tree(ctx, appCtx.Client)
+	if err != nil {
+		return fmt.Errorf("list memberships: %w", err)
+	}
+
+	if appCtx.JSONOutput {
+		return display.PrintJSON(nodes)
+	}
+
+	if len(nodes) == 0 {
+		message.Notify("You have no memberships.")
+		return nil
+	}
+	printTree(nodes)
+	return nil
+}
+
+// printTree prints the top level resources with the resources of
+// organizations below them, one resource per line followed by your roles.
+func printTree(nodes []membership.Node) {
+	for _, node := range nodes {
+		fmt.Printf("%s  %s\n", treeLabel(node.Membership), memberRoles(node.Membership.Roles))
+		printBranches(node.Children, "")
+	}
+}
+
+func printBranches(nodes []membership.Node, prefix string) {
+	for i, node := range nodes {
+		branch, indent := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, indent = "└── ", "    "
+		}
+		fmt.Printf("%s%s%s  %s\n", prefix, branch, treeLabel(node.Membership), memberRoles(node.Membership.Roles))
+		printBranches(node.Children, prefix+indent)
+	}
+}
+
+func treeLabel(m memberships.Membership) string {
+	if m.Resource.Type == membership.TypeMerchant {
+		return fmt.Sprintf("%s (%s)", m.Resource.Name, membership.MerchantCode(m))
+	}
+	return fmt.Sprintf("%s [%s]", m.Resource.Name, m.Resource.Type)
+}
+
+// leaveMerchant removes the authenticated user from a merchant. The
+// memberships API cannot do this, so the member record of the user is looked
+// up by their email and deleted through the members API, which requires
+// permission to manage the members of the merchant.
+func leaveMerchant(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	merchantCode, err := util.RequireSingleArg(cmd, "merchant code")
+	if err != nil {
+		return err
+	}
+
+	path, err := membership.FindMerchant(ctx, appCtx.Client, merchantCode)
+	if err != nil {
+		return fmt.Errorf("find merchant: %w", err)
+	}
+	merchantCode = membership.MerchantCode(path.Membership)
+	if len(path.Organizations) > 0 {
+		return fmt.Errorf("your access to %s comes from the organization %q, leave the organization instead", merchantCode, path.OrganizationPath())
+	}
+
+	account, err := appCtx.Client.Merchant.Get(ctx, merchant.GetAccountParams{})
+	if err != nil {
+		return fmt.Errorf("get account: %w", err)
+	}
+	if account.Account == nil || account.Account.Username == nil {
+		return errors.New("get account: the API did not return your username")
+	}
+	email := *account.Account.Username
+
+	response, err := appCtx.Client.Members.List(ctx, merchantCode, members.ListMerchantMembersParams{Email: &email})
+	if err != nil {
+		return fmt.Errorf("list members: %w", err)
+	}
+	var member *members.Member
+	for i, item := range response.Items {
+		if item.User != nil && strings.EqualFold(item.User.Email, email) {
+			member = &response.Items[i]
+			break
+		}
+	}
+	if member == nil {
+		return fmt.Errorf("%s is not listed as a member of %s", email, merchantCode)
+	}
+
+	memberPath := fmt.Sprintf("/v0.1/merchants/%s/members/%s", merchantCode, member.ID)
+	if appCtx.DryRun {
+		return util.DryRun(appCtx, http.MethodDelete, memberPath, nil)
+	}
+
+	err = util.Confirm(appCtx, util.Confirmation{
+		Action: "leave merchant",
+		Name:   merchantCode,
+		Details: []attribute.KeyValue{
+			attribute.Attribute("Merchant Code", attribute.Styled(merchantCode)),
+			attribute.Attribute("Name", attribute.Styled(path.Membership.Resource.Name)),
+			attribute.Attribute("Your Roles", attribute.Styled(memberRoles(member.Roles))),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = appCtx.Client.Members.Delete(ctx, merchantCode, member.ID)
+	util.RecordAudit(cmd, merchantCode, member.ID, err)
+	if err != nil {
+		return fmt.Errorf("leave merchant: %w", err)
+	}
+
+	message.Success("You left %s", path.Membership.Resource.Name)
+	return nil
+}
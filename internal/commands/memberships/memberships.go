@@ -12,6 +12,7 @@ import (
 	"github.com/sumup/sumup-go/shared"
 
 	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/completion"
 	"github.com/sumup/sumup-cli/internal/display"
 )
 
@@ -55,6 +56,23 @@ func NewCommand() *cli.Command {
 					},
 				},
 			},
+			{
+				Name:   "pending",
+				Usage:  "List invitations addressed to you that you have not accepted yet.",
+				Action: listPending,
+			},
+			{
+				Name:   "tree",
+				Usage:  "Show your organizations and merchants as a tree with your roles.",
+				Action: membershipTree,
+			},
+			{
+				Name:          "leave",
+				Usage:         "Remove your own access to a merchant.",
+				ArgsUsage:     "<merchant-code>",
+				Action:        leaveMerchant,
+				ShellComplete: completion.Complete(completion.MerchantCodes, nil),
+			},
 		},
 	}
 }
@@ -2,18 +2,37 @@ package merchants
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/sumup/sumup-go/merchant"
+	"github.com/sumup/sumup-go/merchants"
+
 	"github.com/sumup/sumup-cli/internal/app"
-	"github.com/sumup/sumup-cli/internal/display/message"
+	"github.com/sumup/sumup-cli/internal/commands/util"
+	"github.com/sumup/sumup-cli/internal/display"
+	"github.com/sumup/sumup-cli/internal/display/attribute"
+	"github.com/sumup/sumup-cli/internal/membership"
 )
 
 func NewCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "merchants",
-		Usage: "Placeholder for the merchants API resource.",
+		Usage: "Commands for merchant accounts.",
 		Commands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "List the merchants you can access through your memberships.",
+				Action: listMerchants,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "org",
+						Usage: "Only list merchants of the organization with this ID and its nested organizations.",
+					},
+				},
+			},
 			{
 				Name:   "get",
 				Usage:  "Get merchant information.",
@@ -30,12 +49,274 @@ func NewCommand() *cli.Command {
 	}
 }
 
-func getMerchant(_ context.Context, cmd *cli.Command) error {
+// merchantProfile is the JSON output of merchants get.
+type merchantProfile struct {
+	*merchants.Merchant
+	// VatID is taken from the legacy merchant profile.
+	VatID *string `json:"vat_id,omitempty"`
+	// BankAccounts are taken from the legacy merchant profile, with masked
+	// account numbers.
+	BankAccounts []merchant.BankAccount `json:"bank_accounts,omitempty"`
+}
+
+func listMerchants(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	items, err := membership.Merchants(ctx, appCtx.Client, cmd.String("org"))
+	if err != nil {
+		return fmt.Errorf("list memberships: %w", err)
+	}
+
+	if appCtx.JSONOutput {
+		return display.PrintJSON(items)
+	}
+
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		roles := "-"
+		if len(item.Roles) > 0 {
+			roles = strings.Join(item.Roles, ", ")
+		}
+		rows = append(rows, []string{
+			membership.MerchantCode(item),
+			item.Resource.Name,
+			roles,
+		})
+	}
+
+	display.RenderTable("Merchants", []string{"Merchant Code", "Name", "Roles"}, rows)
+	return nil
+}
+
+func getMerchant(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
 	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
 	if err != nil {
 		return err
 	}
-	message.Notify("Getting merchant information for: %s", merchantCode)
-	message.Warn("Merchants functionality not yet fully implemented.")
+
+	account, err := appCtx.Client.Merchants.Get(ctx, merchantCode, merchants.GetMerchantParams{})
+	if err != nil {
+		return fmt.Errorf("get merchant: %w", err)
+	}
+	profile := merchantProfile{Merchant: account}
+
+	// Bank accounts and the VAT ID are only available from the legacy profile
+	// of the authenticated merchant. API keys of other merchants or
+	// organizations cannot read it, so a failure only omits them.
+	legacy, err := appCtx.Client.Merchant.GetMerchantProfile(ctx)
+	if err == nil && legacy.MerchantCode != nil && strings.EqualFold(*legacy.MerchantCode, account.MerchantCode) {
+		profile.VatID = legacy.VatId
+		for _, bankAccount := range legacy.BankAccounts {
+			profile.BankAccounts = append(profile.BankAccounts, maskBankAccount(bankAccount))
+		}
+	}
+
+	if appCtx.JSONOutput {
+		return display.PrintJSON(profile)
+	}
+
+	renderMerchant(profile)
 	return nil
 }
+
+func renderMerchant(profile merchantProfile) {
+	account := profile.Merchant
+	details := []attribute.KeyValue{
+		attribute.Attribute("Merchant Code", attribute.Styled(account.MerchantCode)),
+	}
+	if account.Alias != nil {
+		details = append(details, attribute.Attribute("Alias", attribute.Styled(*account.Alias)))
+	}
+
+	var businessName, email, phone, website *string
+	var businessAddress *merchants.Address
+	if business := account.BusinessProfile; business != nil {
+		businessName = business.Name
+		email = business.Email
+		website = business.Website
+		businessAddress = business.Address
+		if business.PhoneNumber != nil {
+			value := string(*business.PhoneNumber)
+			phone = &value
+		}
+	}
+
+	var companyName, legalType *string
+	var companyAddress, tradingAddress *merchants.Address
+	var identifiers merchants.CompanyIdentifiers
+	if company := account.Company; company != nil {
+		companyName = company.Name
+		companyAddress = company.Address
+		tradingAddress = company.TradingAddress
+		identifiers = company.Identifiers
+		if company.LegalType != nil {
+			value := string(*company.LegalType)
+			legalType = &value
+		}
+		if website == nil {
+			website = company.Website
+		}
+		if phone == nil && company.PhoneNumber != nil {
+			value := string(*company.PhoneNumber)
+			phone = &value
+		}
+	}
+
+	details = append(details,
+		attribute.Attribute("Business Name", attribute.Styled(util.StringOrDefault(businessName, util.StringOrDefault(companyName, "-")))),
+		attribute.Attribute("Legal Name", attribute.Styled(util.StringOrDefault(companyName, "-"))),
+		attribute.Attribute("Business Type", attribute.Styled(util.StringOrDefault(account.BusinessType, "-"))),
+		attribute.Attribute("Legal Type", attribute.Styled(util.StringOrDefault(legalType, "-"))),
+		attribute.Attribute("Country", attribute.Styled(string(account.Country))),
+		attribute.Attribute("Default Currency", attribute.Styled(account.DefaultCurrency)),
+		attribute.Attribute("Locale", attribute.Styled(account.DefaultLocale)),
+		attribute.Attribute("Registered Address", attribute.Styled(formatAddress(companyAddress))),
+	)
+	if tradingAddress != nil {
+		details = append(details, attribute.Attribute("Trading Address", attribute.Styled(formatAddress(tradingAddress))))
+	}
+	if businessAddress != nil {
+		details = append(details, attribute.Attribute("Business Address", attribute.Styled(formatAddress(businessAddress))))
+	}
+	details = append(details,
+		attribute.Attribute("Email", attribute.Styled(util.StringOrDefault(email, "-"))),
+		attribute.Attribute("Phone", attribute.Styled(util.StringOrDefault(phone, "-"))),
+		attribute.Attribute("Website", attribute.Styled(util.StringOrDefault(website, "-"))),
+	)
+
+	vatIDs := make([]string, 0, len(identifiers)+1)
+	if profile.VatID != nil && *profile.VatID != "" {
+		vatIDs = append(vatIDs, *profile.VatID)
+	}
+	for _, identifier := range identifiers {
+		if isVatIdentifier(identifier.Ref) && !containsFold(vatIDs, identifier.Value) {
+			vatIDs = append(vatIDs, identifier.Value)
+		}
+	}
+	vat := "-"
+	if len(vatIDs) > 0 {
+		vat = strings.Join(vatIDs, ", ")
+	}
+	details = append(details, attribute.Attribute("VAT IDs", attribute.Styled(vat)))
+	for _, identifier := range identifiers {
+		if !isVatIdentifier(identifier.Ref) {
+			details = append(details, attribute.Attribute(identifierLabel(identifier.Ref), attribute.Styled(identifier.Value)))
+		}
+	}
+	if account.OrganizationId != nil {
+		details = append(details, attribute.Attribute("Organization", attribute.Styled(*account.OrganizationId)))
+	}
+	if account.Sandbox != nil && *account.Sandbox {
+		details = append(details, attribute.Attribute("Sandbox", attribute.Styled("yes")))
+	}
+	display.DataList(details)
+
+	if len(profile.BankAccounts) == 0 {
+		return
+	}
+	fmt.Println()
+	rows := make([][]string, 0, len(profile.BankAccounts))
+	for _, bankAccount := range profile.BankAccounts {
+		primary := ""
+		if bankAccount.Primary != nil && *bankAccount.Primary {
+			primary = "yes"
+		}
+		number := util.StringOrDefault(bankAccount.Iban, util.StringOrDefault(bankAccount.AccountNumber, "-"))
+		rows = append(rows, []string{
+			util.StringOrDefault(bankAccount.BankName, "-"),
+			util.StringOrDefault(bankAccount.AccountHolderName, "-"),
+			number,
+			util.StringOrDefault(bankAccount.Swift, "-"),
+			util.StringOrDefault(bankAccount.Status, "-"),
+			primary,
+		})
+	}
+	display.RenderTable("Bank accounts", []string{"Bank", "Holder", "Account", "BIC", "Status", "Primary"}, rows)
+}
+
+// formatAddress joins the parts of an address that are set into one line.
+func formatAddress(address *merchants.Address) string {
+	if address == nil {
+		return "-"
+	}
+	first := func(values ...*string) string {
+		for _, value := range values {
+			if value != nil && *value != "" {
+				return *value
+			}
+		}
+		return ""
+	}
+
+	parts := append([]string{}, address.StreetAddress...)
+	locality := strings.TrimSpace(first(address.PostCode, address.ZipCode, address.Eircode) + " " + first(address.City, address.PostTown))
+	for _, part := range []string{locality, first(address.Province, address.Region, address.State, address.County), string(address.Country)} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// maskBankAccount hides all but the first and last four characters of the
+// IBAN and all but the last four digits of the account number.
+func maskBankAccount(bankAccount merchant.BankAccount) merchant.BankAccount {
+	if bankAccount.Iban != nil {
+		masked := maskIBAN(*bankAccount.Iban)
+		bankAccount.Iban = &masked
+	}
+	if bankAccount.AccountNumber != nil {
+		masked := maskTail(*bankAccount.AccountNumber, 4)
+		bankAccount.AccountNumber = &masked
+	}
+	return bankAccount
+}
+
+func maskIBAN(iban string) string {
+	iban = strings.ReplaceAll(iban, " ", "")
+	if len(iban) <= 8 {
+		return strings.Repeat("*", len(iban))
+	}
+	return iban[:4] + strings.Repeat("*", len(iban)-8) + iban[len(iban)-4:]
+}
+
+func maskTail(value string, visible int) string {
+	if len(value) <= visible {
+		return strings.Repeat("*", len(value))
+	}
+	return strings.Repeat("*", len(value)-visible) + value[len(value)-visible:]
+}
+
+// isVatIdentifier reports whether a company identifier reference denotes a
+// VAT number, such as "de.vat" or "gb.vat_reg_no".
+func isVatIdentifier(ref string) bool {
+	return strings.Contains(strings.ToLower(ref), "vat")
+}
+
+// identifierLabel turns an identifier reference like "de.hrb" into a label.
+func identifierLabel(ref string) string {
+	if _, name, found := strings.Cut(ref, "."); found {
+		ref = name
+	}
+	return "Identifier " + strings.ToUpper(strings.ReplaceAll(ref, "_", " "))
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,372 @@
+package payouts
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/urfave/cli/v3"
+
+	"github.com/sumup/sumup-go/datetime"
+	"github.com/sumup/sumup-go/payouts"
+	"github.com/sumup/sumup-go/transactions"
+
+	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/commands/util"
+	"github.com/sumup/sumup-cli/internal/display"
+	"github.com/sumup/sumup-cli/internal/display/attribute"
+	"github.com/sumup/sumup-cli/internal/display/message"
+)
+
+const (
+	// lookbackDays and lookaheadDays bound the period searched for a payout
+	// when no dates are given. The API has no endpoint to fetch a single
+	// payout, so it is looked up in the list.
+	lookbackDays  = 90
+	lookaheadDays = 14
+	// transactionLookups bounds the concurrent transaction requests.
+	transactionLookups = 5
+)
+
+// payout is a payout assembled from its lines. The payouts API returns one
+// line per settled transaction or deduction, all sharing the payout ID: the
+// amount of a line is what was paid out for it and the fee what was withheld.
+type payout struct {
+	ID        int                       `json:"id"`
+	Date      string                    `json:"date,omitempty"`
+	Status    string                    `json:"status,omitempty"`
+	Reference string                    `json:"reference,omitempty"`
+	Currency  string                    `json:"currency,omitempty"`
+	Amount    float64                   `json:"amount"`
+	Fee       float64                   `json:"fee"`
+	Lines     []payouts.FinancialPayout `json:"lines"`
+	amount    decimal.Decimal
+	fee       decimal.Decimal
+}
+
+// payoutLine is a transaction or deduction settled in a payout.
+type payoutLine struct {
+	TransactionCode string  `json:"transaction_code,omitempty"`
+	Type            string  `json:"type,omitempty"`
+	Date            string  `json:"date,omitempty"`
+	Gross           float64 `json:"gross"`
+	Fee             float64 `json:"fee"`
+	Net             float64 `json:"net"`
+	Discrepancy     float64 `json:"discrepancy,omitempty"`
+	Note            string  `json:"note,omitempty"`
+
+	gross, fee, net, discrepancy decimal.Decimal
+	verified                     bool
+}
+
+// payoutBreakdown is the output of payouts transactions.
+type payoutBreakdown struct {
+	Payout      *payout      `json:"payout"`
+	Lines       []payoutLine `json:"lines"`
+	Gross       float64      `json:"gross"`
+	Fee         float64      `json:"fee"`
+	Net         float64      `json:"net"`
+	Discrepancy float64      `json:"discrepancy"`
+	Reconciled  bool         `json:"reconciled"`
+}
+
+func payoutLookupFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "merchant-code",
+			Usage:   "Merchant code that received the payout. Falls back to context.",
+			Sources: cli.EnvVars("SUMUP_MERCHANT_CODE"),
+		},
+		&cli.StringFlag{
+			Name:  "start-date",
+			Usage: fmt.Sprintf("Search payouts from this date in YYYY-MM-DD format. Defaults to %d days ago.", lookbackDays),
+		},
+		&cli.StringFlag{
+			Name:  "end-date",
+			Usage: fmt.Sprintf("Search payouts until this date in YYYY-MM-DD format. Defaults to %d days from now.", lookaheadDays),
+		},
+	}
+}
+
+func getPayout(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		return err
+	}
+
+	found, err := lookupPayout(ctx, cmd, appCtx, merchantCode)
+	if err != nil {
+		return err
+	}
+
+	if appCtx.JSONOutput {
+		return display.PrintJSON(found)
+	}
+
+	transactionCount, deductions := 0, 0
+	for _, line := range found.Lines {
+		if line.Type != nil && *line.Type != payouts.FinancialPayoutTypePayout {
+			deductions++
+		} else if line.TransactionCode != nil {
+			transactionCount++
+		}
+	}
+	display.DataList([]attribute.KeyValue{
+		attribute.ID(strconv.Itoa(found.ID)),
+		attribute.Attribute("Date", attribute.Styled(dashIfEmpty(found.Date))),
+		attribute.Attribute("Status", attribute.Styled(dashIfEmpty(found.Status))),
+		attribute.Attribute("Reference", attribute.Styled(dashIfEmpty(found.Reference))),
+		attribute.Attribute("Amount", attribute.Styled(formatMoney(found.amount, found.Currency))),
+		attribute.Attribute("Fee", attribute.Styled(formatMoney(found.fee, found.Currency))),
+		attribute.Attribute("Gross", attribute.Styled(formatMoney(found.amount.Add(found.fee), found.Currency))),
+		attribute.Attribute("Transactions", attribute.Styled(strconv.Itoa(transactionCount))),
+		attribute.Attribute("Deductions", attribute.Styled(strconv.Itoa(deductions))),
+	})
+	return nil
+}
+
+func listPayoutTransactions(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		return err
+	}
+
+	found, err := lookupPayout(ctx, cmd, appCtx, merchantCode)
+	if err != nil {
+		return err
+	}
+
+	breakdown := breakDownPayout(ctx, appCtx, merchantCode, found)
+
+	if appCtx.JSONOutput {
+		if err := display.PrintJSON(breakdown); err != nil {
+			return err
+		}
+	} else {
+		renderBreakdown(breakdown)
+	}
+
+	if !breakdown.Reconciled {
+		return fmt.Errorf("payout %d does not reconcile: gross minus fees differs from the payout amount by %s", found.ID, formatMoney(decimal.NewFromFloat(breakdown.Discrepancy), found.Currency))
+	}
+	return nil
+}
+
+// lookupPayout finds the lines of the payout passed as argument within the
+// period given by --start-date and --end-date.
+func lookupPayout(ctx context.Context, cmd *cli.Command, appCtx *app.Context, merchantCode string) (*payout, error) {
+	arg, err := util.RequireSingleArg(cmd, "payout ID")
+	if err != nil {
+		return nil, err
+	}
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payout ID %q, expected a number", arg)
+	}
+
+	today := time.Now()
+	startDate := datetime.Date{Time: today.AddDate(0, 0, -lookbackDays)}
+	endDate := datetime.Date{Time: today.AddDate(0, 0, lookaheadDays)}
+	if value := cmd.String("start-date"); value != "" {
+		if startDate, err = parseDateArg(value); err != nil {
+			return nil, err
+		}
+	}
+	if value := cmd.String("end-date"); value != "" {
+		if endDate, err = parseDateArg(value); err != nil {
+			return nil, err
+		}
+	}
+
+	payoutList, err := ListAll(ctx, appCtx, merchantCode, startDate.Time, endDate.Time)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []payouts.FinancialPayout
+	for _, line := range payoutList {
+		if line.ID != nil && *line.ID == id {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("payout %d not found between %s and %s, pass --start-date and --end-date to search another period", id, startDate, endDate)
+	}
+	return assemblePayout(id, lines), nil
+}
+
+func assemblePayout(id int, lines []payouts.FinancialPayout) *payout {
+	result := &payout{ID: id, Lines: lines}
+	for _, line := range lines {
+		if result.Date == "" && line.Date != nil {
+			result.Date = line.Date.String()
+		}
+		if result.Reference == "" && line.Reference != nil {
+			result.Reference = *line.Reference
+		}
+		if result.Currency == "" && line.Currency != nil {
+			result.Currency = *line.Currency
+		}
+		// A single failed line means the transfer did not go through.
+		if line.Status != nil && (result.Status == "" || *line.Status == payouts.FinancialPayoutStatusFailed) {
+			result.Status = string(*line.Status)
+		}
+		result.amount = result.amount.Add(decimalOf(line.Amount))
+		result.fee = result.fee.Add(decimalOf(line.Fee))
+	}
+	result.Amount = result.amount.InexactFloat64()
+	result.Fee = result.fee.InexactFloat64()
+	return result
+}
+
+// breakDownPayout looks up the transactions settled in the payout. The gross
+// amount of a sale comes from the transaction, so that gross minus fee can be
+// checked against what was paid out for it. Deductions have no sale of their
+// own and are taken as reported.
+func breakDownPayout(ctx context.Context, appCtx *app.Context, merchantCode string, found *payout) payoutBreakdown {
+	lines := make([]payoutLine, len(found.Lines))
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, transactionLookups)
+	for i, item := range found.Lines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			lines[i] = breakDownLine(ctx, appCtx, merchantCode, item)
+		}()
+	}
+	wg.Wait()
+
+	breakdown := payoutBreakdown{Payout: found, Lines: lines}
+	var gross, fee, net decimal.Decimal
+	for _, line := range lines {
+		gross = gross.Add(line.gross)
+		fee = fee.Add(line.fee)
+		net = net.Add(line.net)
+	}
+	discrepancy := gross.Sub(fee).Sub(found.amount)
+	breakdown.Gross = gross.InexactFloat64()
+	breakdown.Fee = fee.InexactFloat64()
+	breakdown.Net = net.InexactFloat64()
+	breakdown.Discrepancy = discrepancy.InexactFloat64()
+	breakdown.Reconciled = discrepancy.IsZero()
+	for _, line := range lines {
+		breakdown.Reconciled = breakdown.Reconciled && line.verified
+	}
+	return breakdown
+}
+
+func breakDownLine(ctx context.Context, appCtx *app.Context, merchantCode string, item payouts.FinancialPayout) payoutLine {
+	line := payoutLine{
+		TransactionCode: util.StringOrDefault(item.TransactionCode, ""),
+		Type:            enumOrDash(item.Type),
+		fee:             decimalOf(item.Fee),
+		net:             decimalOf(item.Amount),
+		verified:        true,
+	}
+	line.gross = line.net.Add(line.fee)
+
+	if item.TransactionCode != nil && (item.Type == nil || *item.Type == payouts.FinancialPayoutTypePayout) {
+		transaction, err := appCtx.Client.Transactions.Get(ctx, merchantCode, transactions.GetTransactionV21Params{
+			TransactionCode: item.TransactionCode,
+		})
+		switch {
+		case err != nil:
+			line.verified = false
+			line.Note = fmt.Sprintf("transaction not found: %v", err)
+		case transaction.Amount == nil:
+			line.verified = false
+			line.Note = "transaction has no amount"
+		default:
+			line.gross = decimalOf(transaction.Amount)
+			if transaction.Timestamp != nil {
+				line.Date = transaction.Timestamp.Format(time.DateOnly)
+			}
+		}
+	}
+
+	line.discrepancy = line.gross.Sub(line.fee).Sub(line.net)
+	if !line.discrepancy.IsZero() {
+		line.verified = false
+		line.Note = fmt.Sprintf("gross minus fee is off by %s", line.discrepancy.StringFixed(2))
+	}
+	line.Gross = line.gross.InexactFloat64()
+	line.Fee = line.fee.InexactFloat64()
+	line.Net = line.net.InexactFloat64()
+	line.Discrepancy = line.discrepancy.InexactFloat64()
+	return line
+}
+
+func renderBreakdown(breakdown payoutBreakdown) {
+	found := breakdown.Payout
+	rows := make([][]string, 0, len(breakdown.Lines)+1)
+	flagged := 0
+	for _, line := range breakdown.Lines {
+		if !line.verified {
+			flagged++
+		}
+		rows = append(rows, []string{
+			dashIfEmpty(line.TransactionCode),
+			line.Type,
+			dashIfEmpty(line.Date),
+			line.gross.StringFixed(2),
+			line.fee.StringFixed(2),
+			line.net.StringFixed(2),
+			dashIfEmpty(line.Note),
+		})
+	}
+	rows = append(rows, []string{
+		"Total",
+		"",
+		"",
+		decimal.NewFromFloat(breakdown.Gross).StringFixed(2),
+		decimal.NewFromFloat(breakdown.Fee).StringFixed(2),
+		decimal.NewFromFloat(breakdown.Net).StringFixed(2),
+		"",
+	})
+
+	title := fmt.Sprintf("Payout %d · %s · %s", found.ID, dashIfEmpty(found.Date), formatMoney(found.amount, found.Currency))
+	display.RenderTable(title, []string{"Transaction", "Type", "Date", "Gross", "Fee", "Net", "Note"}, rows)
+
+	if flagged > 0 {
+		message.Warn("%d of %d lines do not add up, see the Note column.", flagged, len(breakdown.Lines))
+	}
+	if breakdown.Reconciled {
+		message.Success("Gross minus fees matches the payout amount of %s", formatMoney(found.amount, found.Currency))
+	}
+}
+
+func decimalOf(value *float32) decimal.Decimal {
+	if value == nil {
+		return decimal.Zero
+	}
+	return decimal.NewFromFloat32(*value)
+}
+
+func formatMoney(amount decimal.Decimal, currency string) string {
+	if currency == "" {
+		return amount.StringFixed(2)
+	}
+	return amount.StringFixed(2) + " " + currency
+}
+
+func dashIfEmpty(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
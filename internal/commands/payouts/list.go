@@ -0,0 +1,55 @@
+package payouts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sumup/sumup-go/datetime"
+	"github.com/sumup/sumup-go/payouts"
+
+	"github.com/sumup/sumup-cli/internal/app"
+)
+
+// payoutsPageSize is the number of payout lines requested at once.
+const payoutsPageSize = 1000
+
+// ListAll returns the payout lines dated from start to end, both inclusive,
+// in ascending order. The payouts endpoint cannot page, so a range that
+// fills a whole page is split in halves and fetched again.
+func ListAll(ctx context.Context, appCtx *app.Context, merchantCode string, start, end time.Time) (payouts.FinancialPayouts, error) {
+	start, end = startOfDay(start), startOfDay(end)
+	limit := payoutsPageSize
+	order := "asc"
+	response, err := appCtx.Client.Payouts.List(ctx, merchantCode, payouts.ListPayoutsV1Params{
+		StartDate: datetime.Date{Time: start},
+		EndDate:   datetime.Date{Time: end},
+		Limit:     &limit,
+		Order:     &order,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list payouts: %w", err)
+	}
+	if len(*response) < limit {
+		return *response, nil
+	}
+
+	days := int(end.Sub(start).Hours() / 24)
+	if days < 1 {
+		return nil, fmt.Errorf("more than %d payout lines on %s, the list would be incomplete", limit, start.Format(time.DateOnly))
+	}
+	middle := start.AddDate(0, 0, days/2)
+	first, err := ListAll(ctx, appCtx, merchantCode, start, middle)
+	if err != nil {
+		return nil, err
+	}
+	second, err := ListAll(ctx, appCtx, merchantCode, middle.AddDate(0, 0, 1), end)
+	if err != nil {
+		return nil, err
+	}
+	return append(first, second...), nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
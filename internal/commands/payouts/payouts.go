@@ -19,7 +19,7 @@ import (
 func NewCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "payouts",
-		Usage: "Placeholder for the payouts API resource.",
+		Usage: "Inspect payouts and the transactions settled in them.",
 		Commands: []*cli.Command{
 			{
 				Name:   "list",
@@ -52,6 +52,21 @@ func NewCommand() *cli.Command {
 					},
 				},
 			},
+			{
+				Name:      "get",
+				Usage:     "Show a payout with its fee, status and reference.",
+				ArgsUsage: "<payout-id>",
+				Action:    getPayout,
+				Flags:     payoutLookupFlags(),
+			},
+			{
+				Name:      "transactions",
+				Usage:     "List the transactions settled in a payout and check that they add up to it.",
+				ArgsUsage: "<payout-id>",
+				Action:    listPayoutTransactions,
+				Flags:     payoutLookupFlags(),
+			},
+			reconcileCommand(),
 		},
 	}
 }
@@ -0,0 +1,415 @@
+package payouts
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/sumup/sumup-go/payouts"
+
+	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/display"
+	"github.com/sumup/sumup-cli/internal/display/message"
+	"github.com/sumup/sumup-cli/internal/statement"
+)
+
+// Outcomes of reconciling a statement line or a payout.
+const (
+	matchMatched   = "matched"
+	matchAmbiguous = "ambiguous"
+	matchUnmatched = "unmatched"
+	matchPending   = "pending"
+)
+
+// reconcileItem is a statement line with the payout it was matched to, or a
+// payout that was not found on the statement.
+type reconcileItem struct {
+	Status     string          `json:"status"`
+	Line       *statement.Line `json:"statement_line,omitempty"`
+	Payout     *payout         `json:"payout,omitempty"`
+	Candidates []int           `json:"candidates,omitempty"`
+	Note       string          `json:"note,omitempty"`
+}
+
+func reconcileCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "reconcile",
+		Usage:  "Match the payouts of a merchant against the credits on a bank statement.",
+		Action: reconcilePayouts,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "merchant-code",
+				Usage:   "Merchant code whose payouts should be reconciled. Falls back to context.",
+				Sources: cli.EnvVars("SUMUP_MERCHANT_CODE"),
+			},
+			&cli.StringFlag{
+				Name:     "statement",
+				Usage:    "Bank statement in CAMT.053, MT940 or CSV format.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "statement-format",
+				Usage: "Format of the statement: " + strings.Join(statement.Formats(), ", ") + ".",
+				Value: statement.FormatAuto,
+			},
+			&cli.IntFlag{
+				Name:  "date-window",
+				Usage: "Days a payout may take to be booked on the bank account.",
+				Value: 3,
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Write the results as CSV to this file, for import into bookkeeping tools.",
+			},
+		},
+	}
+}
+
+func reconcilePayouts(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		return err
+	}
+
+	window := cmd.Int("date-window")
+	if window < 0 {
+		return fmt.Errorf("date window must not be negative")
+	}
+
+	lines, err := statement.Read(cmd.String("statement"), cmd.String("statement-format"))
+	if err != nil {
+		return err
+	}
+	var credits []statement.Line
+	for _, line := range lines {
+		if line.Amount.IsPositive() {
+			credits = append(credits, line)
+		}
+	}
+	if len(credits) == 0 {
+		return fmt.Errorf("%s has no credits to match payouts against", cmd.String("statement"))
+	}
+
+	first, last := credits[0].Date, credits[0].Date
+	for _, line := range credits {
+		if line.Date.Before(first) {
+			first = line.Date
+		}
+		if line.Date.After(last) {
+			last = line.Date
+		}
+	}
+
+	// Payouts booked early in the statement may have been paid out up to
+	// the date window before it starts.
+	payoutList, err := ListAll(ctx, appCtx, merchantCode, first.AddDate(0, 0, -window), last)
+	if err != nil {
+		return err
+	}
+
+	items, others := dropOtherCredits(matchStatement(credits, groupPayouts(payoutList), window, first, last))
+
+	if path := cmd.String("output"); path != "" {
+		if err := writeReconciliation(path, items); err != nil {
+			return err
+		}
+	}
+
+	if appCtx.JSONOutput {
+		if err := display.PrintJSON(items); err != nil {
+			return err
+		}
+	} else {
+		renderReconciliation(items, others, len(lines)-len(credits))
+		if path := cmd.String("output"); path != "" {
+			message.Success("Wrote the results to %s", path)
+		}
+	}
+
+	open := countStatus(items, matchAmbiguous) + countStatus(items, matchUnmatched)
+	if open > 0 {
+		return fmt.Errorf("%d of %d items could not be matched", open, len(items))
+	}
+	return nil
+}
+
+// groupPayouts assembles the payouts from their lines, leaving out failed
+// payouts since they never reach the bank account.
+func groupPayouts(list payouts.FinancialPayouts) []*payout {
+	byID := map[int][]payouts.FinancialPayout{}
+	var ids []int
+	for _, line := range list {
+		if line.ID == nil {
+			continue
+		}
+		if _, ok := byID[*line.ID]; !ok {
+			ids = append(ids, *line.ID)
+		}
+		byID[*line.ID] = append(byID[*line.ID], line)
+	}
+
+	result := make([]*payout, 0, len(ids))
+	for _, id := range ids {
+		assembled := assemblePayout(id, byID[id])
+		if assembled.Status == string(payouts.FinancialPayoutStatusFailed) {
+			continue
+		}
+		result = append(result, assembled)
+	}
+	return result
+}
+
+// matchStatement matches the credits to payouts of the same amount and
+// currency that were paid out at most window days before the booking. A
+// payout whose reference or ID appears on the line is preferred; lines that
+// are then still left with several candidates are ambiguous. Payouts dated
+// within the statement period that match no line are reported as unmatched,
+// or as pending when they are recent enough to be booked after the last day
+// of the statement.
+func matchStatement(credits []statement.Line, candidates []*payout, window int, first, last time.Time) []reconcileItem {
+	claimed := map[int]bool{}
+	items := make([]reconcileItem, len(credits))
+	options := make([][]*payout, len(credits))
+
+	// First pass: lines that name exactly one payout.
+	for i, line := range credits {
+		items[i].Line = &credits[i]
+		options[i] = payoutCandidates(line, candidates, window)
+		var named []*payout
+		for _, candidate := range options[i] {
+			if mentionsPayout(line, candidate) {
+				named = append(named, candidate)
+			}
+		}
+		if len(named) == 1 && !claimed[named[0].ID] {
+			items[i].Status = matchMatched
+			items[i].Payout = named[0]
+			items[i].Note = "amount, date and reference"
+			claimed[named[0].ID] = true
+		}
+	}
+
+	// Second pass: the remaining lines by amount and date alone. A payout
+	// that could be booked on several lines leaves them all ambiguous.
+	wanted := map[int]int{}
+	for i := range credits {
+		if items[i].Status != "" {
+			continue
+		}
+		options[i] = slices.DeleteFunc(options[i], func(candidate *payout) bool { return claimed[candidate.ID] })
+		for _, candidate := range options[i] {
+			wanted[candidate.ID]++
+		}
+	}
+	for i := range credits {
+		if items[i].Status != "" {
+			continue
+		}
+		switch {
+		case len(options[i]) == 1 && wanted[options[i][0].ID] == 1:
+			items[i].Status = matchMatched
+			items[i].Payout = options[i][0]
+			items[i].Note = "amount and date"
+			claimed[options[i][0].ID] = true
+		case len(options[i]) > 0:
+			items[i].Status = matchAmbiguous
+			for _, candidate := range options[i] {
+				items[i].Candidates = append(items[i].Candidates, candidate.ID)
+			}
+			items[i].Note = "several payouts have this amount and date"
+			if len(options[i]) == 1 {
+				items[i].Note = "the payout fits several lines"
+			}
+		default:
+			items[i].Status = matchUnmatched
+			items[i].Note = "no payout with this amount and date"
+		}
+	}
+
+	for _, candidate := range candidates {
+		if claimed[candidate.ID] || wanted[candidate.ID] > 0 {
+			continue
+		}
+		date, err := time.Parse(time.DateOnly, candidate.Date)
+		if err != nil || date.Before(first) || date.After(last) {
+			continue
+		}
+		item := reconcileItem{
+			Status: matchUnmatched,
+			Payout: candidate,
+			Note:   "payout not found on the statement",
+		}
+		if date.After(last.AddDate(0, 0, -window)) {
+			item.Status = matchPending
+			item.Note = "payout may be booked after the statement ends"
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// dropOtherCredits removes unmatched lines that are not SumUp payouts, such
+// as transfers from customers, unless they mention SumUp.
+func dropOtherCredits(items []reconcileItem) ([]reconcileItem, int) {
+	before := len(items)
+	items = slices.DeleteFunc(items, func(item reconcileItem) bool {
+		if item.Line == nil || item.Status != matchUnmatched {
+			return false
+		}
+		return !strings.Contains(strings.ToLower(item.Line.Reference+" "+item.Line.Counterparty), "sumup")
+	})
+	return items, before - len(items)
+}
+
+func payoutCandidates(line statement.Line, candidates []*payout, window int) []*payout {
+	var result []*payout
+	for _, candidate := range candidates {
+		if !candidate.amount.Equal(line.Amount) {
+			continue
+		}
+		if line.Currency != "" && candidate.Currency != "" && !strings.EqualFold(line.Currency, candidate.Currency) {
+			continue
+		}
+		date, err := time.Parse(time.DateOnly, candidate.Date)
+		if err != nil {
+			continue
+		}
+		days := int(line.Date.Sub(date).Hours() / 24)
+		if days < 0 || days > window {
+			continue
+		}
+		result = append(result, candidate)
+	}
+	return result
+}
+
+// mentionsPayout reports whether the statement line carries the reference or
+// the ID of the payout. Spaces are ignored since banks wrap long references.
+func mentionsPayout(line statement.Line, candidate *payout) bool {
+	text := strings.ToUpper(strings.ReplaceAll(line.Reference, " ", ""))
+	if reference := strings.ToUpper(strings.ReplaceAll(candidate.Reference, " ", "")); reference != "" && strings.Contains(text, reference) {
+		return true
+	}
+	for _, field := range strings.FieldsFunc(line.Reference, func(r rune) bool { return r < '0' || r > '9' }) {
+		if field == strconv.Itoa(candidate.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+func renderReconciliation(items []reconcileItem, others, debits int) {
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		row := []string{item.Status, "-", "-", "-", "-", "-", item.Note}
+		if item.Line != nil {
+			row[1] = item.Line.Date.Format(time.DateOnly)
+			row[2] = formatMoney(item.Line.Amount, item.Line.Currency)
+			row[3] = dashIfEmpty(truncate(item.Line.Reference, 40))
+		}
+		switch {
+		case item.Payout != nil:
+			row[4] = strconv.Itoa(item.Payout.ID)
+			row[5] = dashIfEmpty(item.Payout.Date)
+			if item.Line == nil {
+				row[2] = formatMoney(item.Payout.amount, item.Payout.Currency)
+			}
+		case len(item.Candidates) > 0:
+			row[4] = joinIDs(item.Candidates)
+		}
+		rows = append(rows, row)
+	}
+	display.RenderTable("Payout reconciliation", []string{"Status", "Booked", "Amount", "Statement Reference", "Payout", "Paid Out", "Note"}, rows)
+
+	message.Notify("Matched: %d, ambiguous: %d, unmatched: %d, pending: %d.",
+		countStatus(items, matchMatched), countStatus(items, matchAmbiguous), countStatus(items, matchUnmatched), countStatus(items, matchPending))
+	if others > 0 {
+		message.Notify("Credits that match no payout and do not mention SumUp were skipped: %d.", others)
+	}
+	if debits > 0 {
+		message.Notify("Debits on the statement are not payouts and were skipped: %d.", debits)
+	}
+}
+
+// writeReconciliation writes one row per item with the statement line and
+// the payout side by side.
+func writeReconciliation(path string, items []reconcileItem) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", path, err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	_ = writer.Write([]string{
+		"status", "booking_date", "amount", "currency", "statement_reference", "counterparty",
+		"payout_id", "payout_date", "payout_amount", "payout_fee", "payout_reference", "candidates", "note",
+	})
+	for _, item := range items {
+		row := make([]string, 13)
+		row[0] = item.Status
+		if item.Line != nil {
+			row[1] = item.Line.Date.Format(time.DateOnly)
+			row[2] = item.Line.Amount.StringFixed(2)
+			row[3] = item.Line.Currency
+			row[4] = item.Line.Reference
+			row[5] = item.Line.Counterparty
+		}
+		if item.Payout != nil {
+			row[6] = strconv.Itoa(item.Payout.ID)
+			row[7] = item.Payout.Date
+			row[8] = item.Payout.amount.StringFixed(2)
+			row[9] = item.Payout.fee.StringFixed(2)
+			row[10] = item.Payout.Reference
+			if row[3] == "" {
+				row[3] = item.Payout.Currency
+			}
+		}
+		row[11] = joinIDs(item.Candidates)
+		row[12] = item.Note
+		_ = writer.Write(row)
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+func countStatus(items []reconcileItem, status string) int {
+	count := 0
+	for _, item := range items {
+		if item.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+func joinIDs(ids []int) string {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, strconv.Itoa(id))
+	}
+	return strings.Join(values, ";")
+}
+
+func truncate(value string, length int) string {
+	runes := []rune(value)
+	if len(runes) <= length {
+		return value
+	}
+	return string(runes[:length-1]) + "…"
+}
@@ -0,0 +1,158 @@
+package payouts
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/sumup/sumup-cli/internal/statement"
+)
+
+func TestMatchStatement(t *testing.T) {
+	newPayout := func(id int, date, amount, reference string) *payout {
+		return &payout{ID: id, Date: date, Currency: "EUR", Reference: reference, amount: decimal.RequireFromString(amount)}
+	}
+	credit := func(date, amount, reference string) statement.Line {
+		day, err := time.Parse(time.DateOnly, date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return statement.Line{Date: day, Amount: decimal.RequireFromString(amount), Currency: "EUR", Reference: reference}
+	}
+
+	// result is the outcome of one item: the status and the payout, or the
+	// candidates of an ambiguous line, or the payout of an item without line.
+	type result struct {
+		status string
+		ids    []int
+	}
+
+	tests := []struct {
+		name    string
+		credits []statement.Line
+		payouts []*payout
+		want    []result
+	}{
+		{
+			name:    "amount and date",
+			credits: []statement.Line{credit("2026-10-12", "10.00", "Gutschrift")},
+			payouts: []*payout{newPayout(1, "2026-10-10", "10.00", "")},
+			want:    []result{{matchMatched, []int{1}}},
+		},
+		{
+			name:    "booked after the date window",
+			credits: []statement.Line{credit("2026-10-14", "10.00", "SumUp")},
+			payouts: []*payout{newPayout(1, "2026-10-10", "10.00", "")},
+			want:    []result{{matchUnmatched, nil}},
+		},
+		{
+			name:    "booked before the payout",
+			credits: []statement.Line{credit("2026-10-09", "10.00", "SumUp")},
+			payouts: []*payout{newPayout(1, "2026-10-10", "10.00", "")},
+			want:    []result{{matchUnmatched, nil}},
+		},
+		{
+			name:    "other currency",
+			credits: []statement.Line{{Date: time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), Amount: decimal.RequireFromString("10"), Currency: "GBP"}},
+			payouts: []*payout{newPayout(1, "2026-10-10", "10.00", "")},
+			// The payout is dated before the statement, so it may have been
+			// booked on the previous one and is not reported.
+			want: []result{{matchUnmatched, nil}},
+		},
+		{
+			name:    "reference decides between payouts of the same amount",
+			credits: []statement.Line{credit("2026-10-12", "40.00", "SUMUP PAYOUT PO-1 04")},
+			payouts: []*payout{newPayout(3, "2026-10-11", "40.00", "PO-103"), newPayout(4, "2026-10-11", "40.00", "PO-104")},
+			want:    []result{{matchMatched, []int{4}}},
+		},
+		{
+			name:    "payout ID decides between payouts of the same amount",
+			credits: []statement.Line{credit("2026-10-12", "40.00", "SumUp 103")},
+			payouts: []*payout{newPayout(103, "2026-10-11", "40.00", ""), newPayout(104, "2026-10-11", "40.00", "")},
+			want:    []result{{matchMatched, []int{103}}},
+		},
+		{
+			name: "named payouts leave the rest to amount and date",
+			credits: []statement.Line{
+				credit("2026-10-12", "40.00", "SumUp"),
+				credit("2026-10-12", "40.00", "SumUp 103"),
+			},
+			payouts: []*payout{newPayout(103, "2026-10-11", "40.00", ""), newPayout(104, "2026-10-11", "40.00", "")},
+			want:    []result{{matchMatched, []int{104}}, {matchMatched, []int{103}}},
+		},
+		{
+			name:    "several payouts fit one line",
+			credits: []statement.Line{credit("2026-10-12", "40.00", "Gutschrift")},
+			payouts: []*payout{newPayout(3, "2026-10-11", "40.00", ""), newPayout(4, "2026-10-10", "40.00", "")},
+			want:    []result{{matchAmbiguous, []int{3, 4}}},
+		},
+		{
+			name: "one payout fits several lines",
+			credits: []statement.Line{
+				credit("2026-10-11", "40.00", "Gutschrift"),
+				credit("2026-10-12", "40.00", "Gutschrift"),
+			},
+			payouts: []*payout{newPayout(3, "2026-10-10", "40.00", "")},
+			want:    []result{{matchAmbiguous, []int{3}}, {matchAmbiguous, []int{3}}},
+		},
+		{
+			name: "missing payouts are unmatched, recent ones pending",
+			credits: []statement.Line{
+				credit("2026-10-01", "1.00", "SumUp 1"),
+				credit("2026-10-20", "2.00", "SumUp 2"),
+			},
+			payouts: []*payout{
+				newPayout(1, "2026-09-30", "1.00", ""),
+				newPayout(2, "2026-10-19", "2.00", ""),
+				newPayout(3, "2026-09-28", "3.00", ""),
+				newPayout(4, "2026-10-10", "4.00", ""),
+				newPayout(5, "2026-10-17", "5.00", ""),
+				newPayout(6, "2026-10-18", "6.00", ""),
+				newPayout(7, "2026-10-20", "7.00", ""),
+			},
+			want: []result{
+				{matchMatched, []int{1}},
+				{matchMatched, []int{2}},
+				{matchUnmatched, []int{4}},
+				{matchUnmatched, []int{5}},
+				{matchPending, []int{6}},
+				{matchPending, []int{7}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, last := tt.credits[0].Date, tt.credits[len(tt.credits)-1].Date
+			items := matchStatement(tt.credits, tt.payouts, 3, first, last)
+
+			got := make([]result, 0, len(items))
+			for _, item := range items {
+				r := result{status: item.Status, ids: item.Candidates}
+				if item.Payout != nil {
+					r.ids = []int{item.Payout.ID}
+				}
+				got = append(got, r)
+			}
+			if !slices.EqualFunc(got, tt.want, func(a, b result) bool {
+				return a.status == b.status && slices.Equal(a.ids, b.ids)
+			}) {
+				t.Errorf("matchStatement() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDropOtherCredits(t *testing.T) {
+	items := []reconcileItem{
+		{Status: matchUnmatched, Line: &statement.Line{Reference: "Rent"}},
+		{Status: matchUnmatched, Line: &statement.Line{Counterparty: "SUMUP PAYMENTS LTD"}},
+		{Status: matchMatched, Line: &statement.Line{Reference: "Gutschrift"}},
+		{Status: matchUnmatched, Payout: &payout{ID: 1}},
+	}
+	kept, dropped := dropOtherCredits(items)
+	if dropped != 1 || len(kept) != 3 || kept[0].Line.Counterparty != "SUMUP PAYMENTS LTD" {
+		t.Errorf("dropOtherCredits() = %+v, %d", kept, dropped)
+	}
+}
@@ -0,0 +1,221 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/config"
+	"github.com/sumup/sumup-cli/internal/display"
+)
+
+// prefix is the executable name prefix of CLI plugins.
+const prefix = "sumup-"
+
+type plugin struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+func NewCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "plugin",
+		Usage: "Manage external sumup-<name> plugin commands.",
+		Commands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "List installed plugins.",
+				Action: listPlugins,
+			},
+		},
+	}
+}
+
+// Run executes the plugin providing the unknown subcommand given as the first
+// argument. It is used as the action of the root command.
+func Run(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args()
+	if args.Len() == 0 {
+		return cli.ShowAppHelp(cmd)
+	}
+
+	name := args.First()
+	path, err := find(name)
+	if err != nil {
+		return err
+	}
+
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+	env, err := environment(appCtx)
+	if err != nil {
+		return err
+	}
+
+	pluginCmd := exec.CommandContext(ctx, path, args.Tail()...)
+	pluginCmd.Stdin = os.Stdin
+	pluginCmd.Stdout = os.Stdout
+	pluginCmd.Stderr = os.Stderr
+	pluginCmd.Env = append(os.Environ(), env...)
+
+	if err := pluginCmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return cli.Exit("", exitErr.ExitCode())
+		}
+		return fmt.Errorf("run plugin %q: %w", name, err)
+	}
+	return nil
+}
+
+func listPlugins(_ context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	plugins, err := discover()
+	if err != nil {
+		return err
+	}
+
+	if appCtx.JSONOutput {
+		return display.PrintJSON(plugins)
+	}
+
+	rows := make([][]string, 0, len(plugins))
+	for _, p := range plugins {
+		rows = append(rows, []string{p.Name, p.Path})
+	}
+
+	display.RenderTable("Plugins", []string{"Name", "Path"}, rows)
+	return nil
+}
+
+// environment returns the variables passing the resolved CLI configuration
+// to a plugin.
+func environment(appCtx *app.Context) ([]string, error) {
+	output := "table"
+	if appCtx.JSONOutput {
+		output = "json"
+	}
+	env := []string{
+		"SUMUP_API_KEY=" + appCtx.APIKey,
+		"SUMUP_BASE_URL=" + appCtx.BaseURL,
+		"SUMUP_OUTPUT=" + output,
+	}
+
+	merchantCode := os.Getenv("SUMUP_MERCHANT_CODE")
+	if merchantCode == "" {
+		var err error
+		merchantCode, err = config.GetCurrentMerchantCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load merchant context: %w", err)
+		}
+	}
+	if merchantCode != "" {
+		env = append(env, "SUMUP_MERCHANT_CODE="+merchantCode)
+	}
+
+	return env, nil
+}
+
+// searchPath returns the directories searched for plugins in order of
+// precedence: the configured plugin directory first, then PATH.
+func searchPath() ([]string, error) {
+	pluginDir, err := config.PluginDir()
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{pluginDir}, filepath.SplitList(os.Getenv("PATH"))...), nil
+}
+
+func find(name string) (string, error) {
+	dirs, err := searchPath()
+	if err != nil {
+		return "", err
+	}
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		for _, candidate := range executableNames(prefix + name) {
+			path := filepath.Join(dir, candidate)
+			if isExecutable(path) {
+				return path, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("unknown command %q. Run 'sumup --help' for usage or install a %s%s plugin", name, prefix, name)
+}
+
+func discover() ([]plugin, error) {
+	dirs, err := searchPath()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	plugins := []plugin{}
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok || seen[name] {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			seen[name] = true
+			plugins = append(plugins, plugin{Name: name, Path: path})
+		}
+	}
+	return plugins, nil
+}
+
+func pluginName(filename string) (string, bool) {
+	if runtime.GOOS == "windows" {
+		filename = strings.TrimSuffix(filename, filepath.Ext(filename))
+	}
+	name, ok := strings.CutPrefix(filename, prefix)
+	if !ok || name == "" {
+		return "", false
+	}
+	return name, true
+}
+
+func executableNames(base string) []string {
+	if runtime.GOOS == "windows" {
+		return []string{base + ".exe", base + ".bat", base + ".cmd"}
+	}
+	return []string{base}
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	return info.Mode().Perm()&0111 != 0
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"net/http"
 	"strings"
 
 	"github.com/urfave/cli/v3"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/sumup/sumup-cli/internal/app"
 	"github.com/sumup/sumup-cli/internal/commands/util"
+	"github.com/sumup/sumup-cli/internal/completion"
 	"github.com/sumup/sumup-cli/internal/currency"
 	"github.com/sumup/sumup-cli/internal/display"
 	"github.com/sumup/sumup-cli/internal/display/attribute"
@@ -27,14 +29,13 @@ func NewCommand() *cli.Command {
 				Name:   "list",
 				Usage:  "List paired readers for a merchant.",
 				Action: listReaders,
-				Flags: []cli.Flag{
+				Flags: append([]cli.Flag{
 					&cli.StringFlag{
-						Name:     "merchant-code",
-						Usage:    "Merchant code whose readers should be listed.",
-						Sources:  cli.EnvVars("SUMUP_MERCHANT_CODE"),
-						Required: true,
+						Name:    "merchant-code",
+						Usage:   "Merchant code whose readers should be listed. Falls back to context.",
+						Sources: cli.EnvVars("SUMUP_MERCHANT_CODE"),
 					},
-				},
+				}, util.FanOutFlags()...),
 			},
 			{
 				Name:   "add",
@@ -60,10 +61,11 @@ func NewCommand() *cli.Command {
 				},
 			},
 			{
-				Name:      "delete",
-				Usage:     "Delete a paired reader from the merchant account.",
-				Action:    deleteReader,
-				ArgsUsage: "<reader-id>",
+				Name:          "delete",
+				Usage:         "Delete a paired reader from the merchant account.",
+				Action:        deleteReader,
+				ArgsUsage:     "<reader-id>",
+				ShellComplete: completion.Complete(completion.Readers, nil),
 				Flags: []cli.Flag{
 					&cli.StringFlag{
 						Name:     "merchant-code",
@@ -74,10 +76,11 @@ func NewCommand() *cli.Command {
 				},
 			},
 			{
-				Name:      "checkout",
-				Usage:     "Trigger a checkout on a specific reader device.",
-				Action:    readerCheckout,
-				ArgsUsage: "<reader-id>",
+				Name:          "checkout",
+				Usage:         "Trigger a checkout on a specific reader device.",
+				Action:        readerCheckout,
+				ArgsUsage:     "<reader-id>",
+				ShellComplete: completion.Complete(completion.Readers, nil),
 				Flags: []cli.Flag{
 					&cli.StringFlag{
 						Name:     "merchant-code",
@@ -108,6 +111,10 @@ func NewCommand() *cli.Command {
 						Name:  "return-url",
 						Usage: "URL that receives the payment result.",
 					},
+					&cli.BoolFlag{
+						Name:  "listen",
+						Usage: "Use the URL of the running 'sumup listen' receiver as the return URL.",
+					},
 					&cli.StringFlag{
 						Name:  "card-type",
 						Usage: "Optional card type hint (required for some countries).",
@@ -147,7 +154,21 @@ func listReaders(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	response, err := appCtx.Client.Readers.List(ctx, cmd.String("merchant-code"))
+
+	merchants, fanOut, err := util.FanOutMerchants(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	if fanOut {
+		return listMerchantsReaders(ctx, cmd, appCtx, merchants)
+	}
+
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		return err
+	}
+
+	response, err := appCtx.Client.Readers.List(ctx, merchantCode)
 	if err != nil {
 		return fmt.Errorf("list readers: %w", err)
 	}
@@ -158,21 +179,53 @@ func listReaders(ctx context.Context, cmd *cli.Command) error {
 
 	rows := make([][]string, 0, len(response.Items))
 	for _, reader := range response.Items {
-		name := string(reader.Name)
-		model := string(reader.Device.Model)
-		rows = append(rows, []string{
-			string(reader.ID),
-			name,
-			string(reader.Status),
-			model,
-			reader.Device.Identifier,
-		})
-	}
-
-	display.RenderTable("Readers", []string{"ID", "Name", "Status", "Model", "Identifier"}, rows)
+		rows = append(rows, readerRow(reader))
+	}
+
+	display.RenderTable("Readers", readerHeaders, rows)
 	return nil
 }
 
+var readerHeaders = []string{"ID", "Name", "Status", "Model", "Identifier"}
+
+// listMerchantsReaders lists the readers of many merchants.
+func listMerchantsReaders(ctx context.Context, cmd *cli.Command, appCtx *app.Context, merchants []util.Merchant) error {
+	results := util.FanOut(ctx, cmd, merchants, func(ctx context.Context, merchantCode string) ([]readers.Reader, error) {
+		response, err := appCtx.Client.Readers.List(ctx, merchantCode)
+		if err != nil {
+			return nil, fmt.Errorf("list readers: %w", err)
+		}
+		return response.Items, nil
+	})
+
+	if appCtx.JSONOutput {
+		if err := display.PrintJSON(results); err != nil {
+			return err
+		}
+		return util.FanOutErrors(appCtx, results)
+	}
+
+	var rows [][]string
+	for _, result := range results {
+		for _, reader := range result.Result {
+			rows = append(rows, append([]string{result.MerchantCode}, readerRow(reader)...))
+		}
+	}
+
+	display.RenderTable("Readers", append([]string{"Merchant"}, readerHeaders...), rows)
+	return util.FanOutErrors(appCtx, results)
+}
+
+func readerRow(reader readers.Reader) []string {
+	return []string{
+		string(reader.ID),
+		string(reader.Name),
+		string(reader.Status),
+		string(reader.Device.Model),
+		reader.Device.Identifier,
+	}
+}
+
 func addReader(ctx context.Context, cmd *cli.Command) error {
 	appCtx, err := app.GetAppContext(cmd)
 	if err != nil {
@@ -183,10 +236,17 @@ func addReader(ctx context.Context, cmd *cli.Command) error {
 		Name:        readers.ReaderName(cmd.String("name")),
 	}
 
+	if appCtx.DryRun {
+		path := fmt.Sprintf("/v0.1/merchants/%s/readers", cmd.String("merchant-code"))
+		return util.DryRun(appCtx, http.MethodPost, path, body)
+	}
+
 	reader, err := appCtx.Client.Readers.Create(ctx, cmd.String("merchant-code"), body)
 	if err != nil {
+		util.RecordAudit(cmd, cmd.String("merchant-code"), "", err)
 		return fmt.Errorf("create reader: %w", err)
 	}
+	util.RecordAudit(cmd, cmd.String("merchant-code"), string(reader.ID), nil)
 
 	if appCtx.JSONOutput {
 		return display.PrintJSON(reader)
@@ -213,7 +273,32 @@ func deleteReader(ctx context.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	err = appCtx.Client.Readers.Delete(ctx, cmd.String("merchant-code"), readers.ReaderId(readerID))
+	if appCtx.DryRun {
+		path := fmt.Sprintf("/v0.1/merchants/%s/readers/%s", cmd.String("merchant-code"), readerID)
+		return util.DryRun(appCtx, http.MethodDelete, path, nil)
+	}
+
+	reader, err := appCtx.Client.Readers.Get(ctx, cmd.String("merchant-code"), readers.ReaderId(readerID), readers.GetReaderParams{})
+	if err != nil {
+		return fmt.Errorf("retrieve reader: %w", err)
+	}
+	err = util.Confirm(appCtx, util.Confirmation{
+		Action: "delete reader",
+		Name:   string(reader.Name),
+		Details: []attribute.KeyValue{
+			attribute.ID(string(reader.ID)),
+			attribute.Attribute("Name", attribute.Styled(string(reader.Name))),
+			attribute.Attribute("Model", attribute.Styled(string(reader.Device.Model))),
+			attribute.Attribute("Status", attribute.Styled(string(reader.Status))),
+			attribute.Attribute("Identifier", attribute.Styled(reader.Device.Identifier)),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = appCtx.Client.Readers.Delete(ctx, cmd.String("merchant-code"), reader.ID)
+	util.RecordAudit(cmd, cmd.String("merchant-code"), readerID, err)
 	if err != nil {
 		return fmt.Errorf("delete reader: %w", err)
 	}
@@ -243,7 +328,10 @@ func readerCheckout(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	if value > int64(math.MaxInt32) || value < int64(math.MinInt32) {
+	if value <= 0 {
+		return fmt.Errorf("amount must be greater than zero")
+	}
+	if value > int64(math.MaxInt32) {
 		return fmt.Errorf("amount is too large to convert into minor units")
 	}
 
@@ -258,7 +346,11 @@ func readerCheckout(ctx context.Context, cmd *cli.Command) error {
 	if desc := cmd.String("description"); desc != "" {
 		body.Description = &desc
 	}
-	if returnURL := cmd.String("return-url"); returnURL != "" {
+	returnURL, err := util.ReturnURL(cmd)
+	if err != nil {
+		return err
+	}
+	if returnURL != "" {
 		body.ReturnUrl = &returnURL
 	}
 	if cardType := cmd.String("card-type"); cardType != "" {
@@ -288,10 +380,17 @@ func readerCheckout(ctx context.Context, cmd *cli.Command) error {
 		body.Affiliate = affiliate
 	}
 
+	if appCtx.DryRun {
+		path := fmt.Sprintf("/v0.1/merchants/%s/readers/%s/checkout", cmd.String("merchant-code"), readerID)
+		return util.DryRun(appCtx, http.MethodPost, path, body)
+	}
+
 	response, err := appCtx.Client.Readers.CreateCheckout(ctx, cmd.String("merchant-code"), readerID, body)
 	if err != nil {
+		util.RecordAudit(cmd, cmd.String("merchant-code"), readerID, err)
 		return fmt.Errorf("trigger reader checkout: %w", err)
 	}
+	util.RecordAudit(cmd, cmd.String("merchant-code"), response.Data.ClientTransactionId, nil)
 
 	if appCtx.JSONOutput {
 		return display.PrintJSON(response)
@@ -79,9 +79,16 @@ func getReceipt(ctx context.Context, cmd *cli.Command) error {
 }
 
 func renderReceipt(receipt *receipts.Receipt) {
+	fmt.Print(Format(receipt))
+}
+
+// Format renders a receipt as text, for printing or embedding in
+// interactive views.
+func Format(receipt *receipts.Receipt) string {
+	var b strings.Builder
 	if transaction := receipt.TransactionData; transaction != nil {
-		fmt.Println("Transaction")
-		display.DataList([]attribute.KeyValue{
+		fmt.Fprintln(&b, "Transaction")
+		b.WriteString(display.DataListString([]attribute.KeyValue{
 			attribute.Attribute("Code", attribute.Styled(util.StringOrDefault(transaction.TransactionCode, "-"))),
 			attribute.Attribute("Status", attribute.Styled(util.StringOrDefault(transaction.Status, "-"))),
 			attribute.Attribute("Payment Type", attribute.Styled(util.StringOrDefault(transaction.PaymentType, "-"))),
@@ -90,13 +97,13 @@ func renderReceipt(receipt *receipts.Receipt) {
 			attribute.Attribute("Entry Mode", attribute.Styled(util.StringOrDefault(transaction.EntryMode, "-"))),
 			attribute.Attribute("Verification", attribute.Styled(util.StringOrDefault(transaction.VerificationMethod, "-"))),
 			attribute.Attribute("Card", attribute.Styled(receiptCard(transaction))),
-		})
+		}))
 	} else {
-		fmt.Println("Transaction: -")
+		fmt.Fprintln(&b, "Transaction: -")
 	}
 
 	if merchant := receipt.MerchantData; merchant != nil {
-		fmt.Println("\nMerchant")
+		fmt.Fprintln(&b, "\nMerchant")
 		pairs := make([]attribute.KeyValue, 0, 5)
 		if profile := merchant.MerchantProfile; profile != nil {
 			pairs = append(pairs, attribute.Attribute("Name", attribute.Styled(util.StringOrDefault(profile.BusinessName, "-"))))
@@ -108,32 +115,33 @@ func renderReceipt(receipt *receipts.Receipt) {
 			}
 			pairs = append(pairs, attribute.Attribute("Email", attribute.Styled(util.StringOrDefault(profile.Email, "-"))))
 		} else {
-			fmt.Println("Merchant profile unavailable")
+			fmt.Fprintln(&b, "Merchant profile unavailable")
 		}
 		if merchant.Locale != nil && *merchant.Locale != "" {
 			pairs = append(pairs, attribute.Attribute("Locale", attribute.Styled(*merchant.Locale)))
 		}
-		display.DataList(pairs)
+		b.WriteString(display.DataListString(pairs))
 	}
 
 	if acquirer := receipt.AcquirerData; acquirer != nil {
-		fmt.Println("\nAcquirer")
-		display.DataList([]attribute.KeyValue{
+		fmt.Fprintln(&b, "\nAcquirer")
+		b.WriteString(display.DataListString([]attribute.KeyValue{
 			attribute.Attribute("Terminal ID", attribute.Styled(util.StringOrDefault(acquirer.Tid, "-"))),
 			attribute.Attribute("Authorization Code", attribute.Styled(util.StringOrDefault(acquirer.AuthorizationCode, "-"))),
 			attribute.Attribute("Return Code", attribute.Styled(util.StringOrDefault(acquirer.ReturnCode, "-"))),
 			attribute.Attribute("Local Time", attribute.Styled(util.StringOrDefault(acquirer.LocalTime, "-"))),
-		})
+		}))
 	}
 
 	if transaction := receipt.TransactionData; transaction != nil {
 		if len(transaction.Events) > 0 {
-			fmt.Printf("\nEvents (%d)\n", len(transaction.Events))
+			fmt.Fprintf(&b, "\nEvents (%d)\n", len(transaction.Events))
 			for _, event := range transaction.Events {
-				fmt.Printf("  - %s %s\n", enumValue(event.Type), enumValue(event.Status))
+				fmt.Fprintf(&b, "  - %s %s\n", enumValue(event.Type), enumValue(event.Status))
 			}
 		}
 	}
+	return b.String()
 }
 
 func receiptAmount(transaction *receipts.ReceiptTransaction) string {
@@ -2,19 +2,24 @@ package roles
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/sumup/sumup-go/roles"
+
 	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/commands/util"
+	"github.com/sumup/sumup-cli/internal/completion"
 	"github.com/sumup/sumup-cli/internal/display"
+	"github.com/sumup/sumup-cli/internal/display/attribute"
+	"github.com/sumup/sumup-cli/internal/display/message"
 )
 
-type role struct {
-	Name        string `json:"name"`
-	DisplayName string `json:"display_name"`
-	Description string `json:"description"`
-}
-
 func NewCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "roles",
@@ -22,59 +27,440 @@ func NewCommand() *cli.Command {
 		Commands: []*cli.Command{
 			{
 				Name:   "list",
-				Usage:  "List available roles.",
+				Usage:  "List the predefined and custom roles of a merchant.",
 				Action: listRoles,
-				Flags:  []cli.Flag{},
+				Flags: []cli.Flag{
+					merchantCodeFlag("Merchant code whose roles should be listed. Falls back to context."),
+				},
+			},
+			{
+				Name:          "get",
+				Usage:         "Get a role and its permissions.",
+				Action:        getRole,
+				ArgsUsage:     "<role-id>",
+				ShellComplete: completion.Complete(completion.Roles, nil),
+				Flags: []cli.Flag{
+					merchantCodeFlag("Merchant code the role belongs to. Falls back to context."),
+				},
+			},
+			{
+				Name:   "create",
+				Usage:  "Create a custom role.",
+				Action: createRole,
+				Flags: []cli.Flag{
+					merchantCodeFlag("Merchant code for the new role. Falls back to context."),
+					&cli.StringFlag{
+						Name:     "name",
+						Usage:    "Name of the role.",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "description",
+						Usage: "Description of the role.",
+					},
+					&cli.StringSliceFlag{
+						Name:     "permission",
+						Usage:    "Permission granted by the role (repeat flag for multiple permissions).",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:          "update",
+				Usage:         "Update a custom role.",
+				Action:        updateRole,
+				ArgsUsage:     "<role-id>",
+				ShellComplete: completion.Complete(completion.Roles, nil),
+				Flags: []cli.Flag{
+					merchantCodeFlag("Merchant code the role belongs to. Falls back to context."),
+					&cli.StringFlag{
+						Name:  "name",
+						Usage: "New name of the role.",
+					},
+					&cli.StringFlag{
+						Name:  "description",
+						Usage: "New description of the role.",
+					},
+					&cli.StringSliceFlag{
+						Name:  "permission",
+						Usage: "Permissions granted by the role, replacing the current ones (repeat flag for multiple permissions).",
+					},
+				},
+			},
+			{
+				Name:          "delete",
+				Usage:         "Delete a custom role.",
+				Action:        deleteRole,
+				ArgsUsage:     "<role-id>",
+				ShellComplete: completion.Complete(completion.Roles, nil),
+				Flags: []cli.Flag{
+					merchantCodeFlag("Merchant code the role belongs to. Falls back to context."),
+				},
 			},
 		},
 	}
 }
 
-func listRoles(_ context.Context, cmd *cli.Command) error {
+func merchantCodeFlag(usage string) cli.Flag {
+	return &cli.StringFlag{
+		Name:    "merchant-code",
+		Usage:   usage,
+		Sources: cli.EnvVars("SUMUP_MERCHANT_CODE"),
+	}
+}
+
+func listRoles(ctx context.Context, cmd *cli.Command) error {
 	appCtx, err := app.GetAppContext(cmd)
 	if err != nil {
 		return err
 	}
-	roles := defaultRoles()
+
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		return err
+	}
+
+	response, err := appCtx.Client.Roles.List(ctx, merchantCode)
+	if err != nil {
+		return fmt.Errorf("list roles: %w", err)
+	}
+
+	items := predefinedFirst(response.Items)
 	if appCtx.JSONOutput {
-		return display.PrintJSON(roles)
+		return display.PrintJSON(items)
 	}
 
-	rows := make([][]string, 0, len(roles))
-	for _, role := range roles {
-		rows = append(rows, []string{role.Name, role.DisplayName, role.Description})
+	// Without predefined roles from the API, show the usual ones so that
+	// the list is complete, but marked as not confirmed for the merchant.
+	var assumed []roles.Role
+	if !hasPredefined(items) {
+		assumed = assumedPredefinedRoles()
+	}
+
+	rows := make([][]string, 0, len(assumed)+len(items))
+	for _, role := range assumed {
+		rows = append(rows, []string{
+			role.ID,
+			role.Name,
+			roleKind(role) + " (assumed)",
+			"-",
+			util.StringOrDefault(role.Description, "-"),
+		})
+	}
+	for _, role := range items {
+		rows = append(rows, []string{
+			role.ID,
+			role.Name,
+			roleKind(role),
+			rolePermissions(role.Permissions),
+			util.StringOrDefault(role.Description, "-"),
+		})
 	}
 
-	display.RenderTable("Roles", []string{"Role", "Display Name", "Description"}, rows)
+	display.RenderTable("Roles", []string{"ID", "Name", "Type", "Permissions", "Description"}, rows)
+	if len(assumed) > 0 {
+		message.Notify("The API listed no predefined roles for %s, the roles marked as assumed may not exist for this merchant.", merchantCode)
+	}
 	return nil
 }
 
-func defaultRoles() []role {
-	return []role{
-		{
-			Name:        "role_owner",
-			DisplayName: "Owner",
-			Description: "Full administrative access to the merchant account",
-		},
-		{
-			Name:        "role_admin",
-			DisplayName: "Admin",
-			Description: "Administrative access with some restrictions",
-		},
-		{
-			Name:        "role_employee",
-			DisplayName: "Employee",
-			Description: "Standard employee access for daily operations",
-		},
-		{
-			Name:        "role_manager",
-			DisplayName: "Manager",
-			Description: "Management access with elevated permissions",
-		},
-		{
-			Name:        "role_cashier",
-			DisplayName: "Cashier",
-			Description: "Limited access for point-of-sale operations",
+func getRole(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		return err
+	}
+
+	roleID, err := util.RequireSingleArg(cmd, "role ID")
+	if err != nil {
+		return err
+	}
+
+	role, err := appCtx.Client.Roles.Get(ctx, merchantCode, roleID)
+	if err != nil {
+		return fmt.Errorf("retrieve role: %w", err)
+	}
+
+	if appCtx.JSONOutput {
+		return display.PrintJSON(role)
+	}
+
+	renderRole(appCtx, role)
+	return nil
+}
+
+func createRole(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		return err
+	}
+
+	body := roles.CreateMerchantRoleBody{
+		Name:        cmd.String("name"),
+		Permissions: cmd.StringSlice("permission"),
+	}
+	if description := cmd.String("description"); description != "" {
+		body.Description = &description
+	}
+
+	if appCtx.DryRun {
+		return util.DryRun(appCtx, http.MethodPost, rolesPath(merchantCode), body)
+	}
+
+	role, err := appCtx.Client.Roles.Create(ctx, merchantCode, body)
+	if err != nil {
+		util.RecordAudit(cmd, merchantCode, "", err)
+		return fmt.Errorf("create role: %w", err)
+	}
+	util.RecordAudit(cmd, merchantCode, role.ID, nil)
+
+	if appCtx.JSONOutput {
+		return display.PrintJSON(role)
+	}
+
+	message.Success("Role created")
+	renderRole(appCtx, role)
+	return nil
+}
+
+func updateRole(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		return err
+	}
+
+	roleID, err := util.RequireSingleArg(cmd, "role ID")
+	if err != nil {
+		return err
+	}
+
+	body := roles.UpdateMerchantRoleBody{}
+	if cmd.IsSet("name") {
+		name := cmd.String("name")
+		body.Name = &name
+	}
+	if cmd.IsSet("description") {
+		description := cmd.String("description")
+		body.Description = &description
+	}
+	if cmd.IsSet("permission") {
+		body.Permissions = cmd.StringSlice("permission")
+	}
+	if body.Name == nil && body.Description == nil && body.Permissions == nil {
+		return errors.New("nothing to update, pass --name, --description or --permission")
+	}
+
+	if appCtx.DryRun {
+		return util.DryRun(appCtx, http.MethodPatch, rolesPath(merchantCode)+"/"+roleID, body)
+	}
+
+	role, err := appCtx.Client.Roles.Update(ctx, merchantCode, roleID, body)
+	util.RecordAudit(cmd, merchantCode, roleID, err)
+	if err != nil {
+		return fmt.Errorf("update role: %w", err)
+	}
+
+	if appCtx.JSONOutput {
+		return display.PrintJSON(role)
+	}
+
+	message.Success("Role updated")
+	renderRole(appCtx, role)
+	return nil
+}
+
+func deleteRole(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		return err
+	}
+
+	roleID, err := util.RequireSingleArg(cmd, "role ID")
+	if err != nil {
+		return err
+	}
+
+	if appCtx.DryRun {
+		return util.DryRun(appCtx, http.MethodDelete, rolesPath(merchantCode)+"/"+roleID, nil)
+	}
+
+	role, err := appCtx.Client.Roles.Get(ctx, merchantCode, roleID)
+	if err != nil {
+		return fmt.Errorf("retrieve role: %w", err)
+	}
+	if role.IsPredefined {
+		return fmt.Errorf("role %s is predefined and cannot be deleted", role.ID)
+	}
+	err = util.Confirm(appCtx, util.Confirmation{
+		Action: "delete role",
+		Name:   role.Name,
+		Details: []attribute.KeyValue{
+			attribute.ID(role.ID),
+			attribute.Attribute("Name", attribute.Styled(role.Name)),
+			attribute.Attribute("Permissions", attribute.Styled(rolePermissions(role.Permissions))),
 		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = appCtx.Client.Roles.Delete(ctx, merchantCode, roleID)
+	util.RecordAudit(cmd, merchantCode, roleID, err)
+	if err != nil {
+		return fmt.Errorf("delete role: %w", err)
+	}
+
+	message.Success("Role deleted")
+	return nil
+}
+
+// assumedPredefinedRoles returns the roles SumUp usually provides to every
+// merchant. They are only shown, marked as assumed, when the roles endpoint
+// does not list any predefined role for the merchant.
+func assumedPredefinedRoles() []roles.Role {
+	return []roles.Role{
+		predefinedRole("role_owner", "Owner", "Full administrative access to the merchant account"),
+		predefinedRole("role_admin", "Admin", "Administrative access with some restrictions"),
+		predefinedRole("role_employee", "Employee", "Standard employee access for daily operations"),
+		predefinedRole("role_manager", "Manager", "Management access with elevated permissions"),
+		predefinedRole("role_cashier", "Cashier", "Limited access for point-of-sale operations"),
+	}
+}
+
+func predefinedRole(id, name, description string) roles.Role {
+	return roles.Role{
+		ID:           id,
+		Name:         name,
+		Description:  &description,
+		IsPredefined: true,
+	}
+}
+
+// hasPredefined reports whether the API listed any predefined role, which
+// confirms the predefined roles of the merchant.
+func hasPredefined(items []roles.Role) bool {
+	return slices.ContainsFunc(items, func(role roles.Role) bool { return role.IsPredefined })
+}
+
+// predefinedFirst lists the predefined roles before the custom ones.
+func predefinedFirst(items []roles.Role) []roles.Role {
+	sorted := slices.Clone(items)
+	slices.SortStableFunc(sorted, func(a, b roles.Role) int {
+		switch {
+		case a.IsPredefined == b.IsPredefined:
+			return 0
+		case a.IsPredefined:
+			return -1
+		default:
+			return 1
+		}
+	})
+	return sorted
+}
+
+// Resolve maps role IDs or names to the IDs of the given roles. Values that
+// match no role are reported together with the closest role, if any.
+//
+// When the roles do not include any predefined role, the predefined roles of
+// the merchant are unknown: IDs of the usually predefined roles are then
+// passed on and returned as unconfirmed as well, so that the API decides.
+func Resolve(items []roles.Role, values []string) (ids, unconfirmed []string, err error) {
+	candidates := make([]string, 0, 2*len(items))
+	for _, role := range items {
+		candidates = append(candidates, role.ID, role.Name)
+	}
+	var assumed []roles.Role
+	if !hasPredefined(items) {
+		assumed = assumedPredefinedRoles()
+		for _, role := range assumed {
+			candidates = append(candidates, role.ID)
+		}
+	}
+
+	ids = make([]string, 0, len(values))
+	var problems []string
+	for _, value := range values {
+		if id, ok := findRole(items, value); ok {
+			ids = append(ids, id)
+			continue
+		}
+		if slices.ContainsFunc(assumed, func(role roles.Role) bool { return role.ID == value }) {
+			ids = append(ids, value)
+			unconfirmed = append(unconfirmed, value)
+			continue
+		}
+		problem := fmt.Sprintf("unknown role %q", value)
+		if suggestion := util.Suggest(value, candidates); suggestion != "" {
+			problem += fmt.Sprintf(", did you mean %q?", suggestion)
+		}
+		problems = append(problems, problem)
+	}
+	if len(problems) > 0 {
+		return nil, nil, fmt.Errorf("%s (see 'sumup roles list')", strings.Join(problems, "; "))
+	}
+	return ids, unconfirmed, nil
+}
+
+// findRole looks a role up by its ID, or by its name ignoring case.
+func findRole(items []roles.Role, value string) (string, bool) {
+	for _, role := range items {
+		if role.ID == value {
+			return role.ID, true
+		}
+	}
+	for _, role := range items {
+		if strings.EqualFold(role.Name, value) {
+			return role.ID, true
+		}
 	}
+	return "", false
+}
+
+func renderRole(appCtx *app.Context, role *roles.Role) {
+	display.DataList([]attribute.KeyValue{
+		attribute.ID(role.ID),
+		attribute.Attribute("Name", attribute.Styled(role.Name)),
+		attribute.Attribute("Type", attribute.Styled(roleKind(*role))),
+		attribute.Attribute("Description", attribute.Styled(util.StringOrDefault(role.Description, "-"))),
+		attribute.Attribute("Permissions", attribute.Styled(rolePermissions(role.Permissions))),
+		attribute.Attribute("Created At", attribute.Styled(util.TimeOrDash(appCtx, &role.CreatedAt))),
+		attribute.Attribute("Updated At", attribute.Styled(util.TimeOrDash(appCtx, &role.UpdatedAt))),
+	})
+}
+
+func roleKind(role roles.Role) string {
+	if role.IsPredefined {
+		return "Predefined"
+	}
+	return "Custom"
+}
+
+func rolePermissions(permissions []string) string {
+	if len(permissions) == 0 {
+		return "-"
+	}
+	return strings.Join(permissions, ", ")
+}
+
+func rolesPath(merchantCode string) string {
+	return fmt.Sprintf("/v0.1/merchants/%s/roles", merchantCode)
 }
@@ -0,0 +1,322 @@
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/urfave/cli/v3"
+
+	"github.com/sumup/sumup-cli/internal/completion"
+	"github.com/sumup/sumup-cli/internal/config"
+)
+
+// maxCandidates limits the completion candidates listed below the prompt.
+const maxCandidates = 8
+
+// errExit is returned by readLine when the user leaves the shell.
+var errExit = errors.New("exit shell")
+
+// builtins are the commands handled by the shell itself.
+var builtins = []string{"exit", "use"}
+
+// flagValuesMsg delivers the completion values of a flag loaded in the
+// background.
+type flagValuesMsg struct {
+	flag   string
+	values []string
+}
+
+type editor struct {
+	ctx     context.Context
+	session *session
+	// Command tree used to complete commands and flags
+	root *cli.Command
+	// Alias names from the configuration
+	aliases []string
+	input   textinput.Model
+	// Position in the history while browsing it with up/down
+	historyIndex int
+	// Line typed before browsing the history
+	draft string
+	// Whether the line has been submitted or cancelled
+	done bool
+	// Whether the user asked to leave the shell
+	exit bool
+}
+
+// readLine reads one line from the terminal with history and completion.
+// Cancelling the line with ctrl+c returns an empty line.
+func readLine(ctx context.Context, s *session) (string, error) {
+	input := textinput.New()
+	input.Prompt = s.prompt()
+	input.ShowSuggestions = true
+	input.CompletionStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	// up and down browse the history instead of the suggestions.
+	input.KeyMap.NextSuggestion = key.NewBinding(key.WithKeys("ctrl+n"))
+	input.KeyMap.PrevSuggestion = key.NewBinding(key.WithKeys("ctrl+p"))
+	input.Focus()
+
+	var aliases []string
+	if cfg, err := config.Load(); err == nil {
+		for name := range cfg.Aliases {
+			aliases = append(aliases, name)
+		}
+	}
+
+	m := editor{
+		ctx:          ctx,
+		session:      s,
+		root:         s.newRoot(),
+		aliases:      aliases,
+		input:        input,
+		historyIndex: len(s.history),
+	}
+	result, err := tea.NewProgram(m, tea.WithContext(ctx)).Run()
+	// Loads still running when the line is submitted deliver their values
+	// to an editor that is gone, so the next one has to start them again.
+	s.loadingFlags = nil
+	if err != nil {
+		return "", fmt.Errorf("read input: %w", err)
+	}
+
+	final := result.(editor)
+	if final.exit {
+		return "", errExit
+	}
+	return final.input.Value(), nil
+}
+
+func (m editor) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m editor) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case flagValuesMsg:
+		if m.session.flagValues == nil {
+			m.session.flagValues = map[string][]string{}
+		}
+		m.session.flagValues[msg.flag] = msg.values
+		delete(m.session.loadingFlags, msg.flag)
+		return m, m.refreshSuggestions()
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.input.SetValue("")
+			m.done = true
+			return m, tea.Quit
+		case "ctrl+d":
+			if m.input.Value() == "" {
+				m.exit = true
+				m.done = true
+				return m, tea.Quit
+			}
+		case "enter":
+			m.done = true
+			return m, tea.Quit
+		case "up":
+			if m.historyIndex > 0 {
+				if m.historyIndex == len(m.session.history) {
+					m.draft = m.input.Value()
+				}
+				m.historyIndex--
+				m.input.SetValue(m.session.history[m.historyIndex])
+				m.input.CursorEnd()
+			}
+			return m, nil
+		case "down":
+			if m.historyIndex < len(m.session.history) {
+				m.historyIndex++
+				if m.historyIndex == len(m.session.history) {
+					m.input.SetValue(m.draft)
+				} else {
+					m.input.SetValue(m.session.history[m.historyIndex])
+				}
+				m.input.CursorEnd()
+			}
+			return m, nil
+		}
+	}
+
+	oldValue := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() != oldValue {
+		return m, tea.Batch(cmd, m.refreshSuggestions())
+	}
+	return m, cmd
+}
+
+func (m editor) View() string {
+	if m.done {
+		if m.exit {
+			return m.input.Prompt + "\n"
+		}
+		return m.input.Prompt + m.input.Value() + "\n"
+	}
+
+	view := m.input.View()
+	matched := m.input.MatchedSuggestions()
+	if len(matched) > 1 {
+		_, current := splitCurrent(m.input.Value())
+		prefix := strings.TrimSuffix(m.input.Value(), current)
+		words := make([]string, 0, min(len(matched), maxCandidates))
+		for _, suggestion := range matched[:min(len(matched), maxCandidates)] {
+			words = append(words, strings.TrimSpace(strings.TrimPrefix(suggestion, prefix)))
+		}
+		if len(matched) > maxCandidates {
+			words = append(words, "…")
+		}
+		hint := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+		view += "\n" + hint.Render(strings.Join(words, "  "))
+	}
+	return view
+}
+
+// refreshSuggestions updates the suggestions for the current input. Values
+// of flags that need an API call are loaded in the background.
+func (m *editor) refreshSuggestions() tea.Cmd {
+	value := m.input.Value()
+	candidates, pending := m.candidates(value)
+
+	_, current := splitCurrent(value)
+	prefix := strings.TrimSuffix(value, current)
+	suggestions := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		suggestions = append(suggestions, prefix+candidate+" ")
+	}
+	m.input.SetSuggestions(suggestions)
+
+	if pending == "" || m.session.loadingFlags[pending] {
+		return nil
+	}
+	if m.session.loadingFlags == nil {
+		m.session.loadingFlags = map[string]bool{}
+	}
+	m.session.loadingFlags[pending] = true
+	return m.loadFlagValues(pending)
+}
+
+// candidates returns the words that can complete the word under the cursor.
+// If they depend on flag values that are not loaded yet, the flag name is
+// returned as pending.
+func (m *editor) candidates(value string) ([]string, string) {
+	words, current := splitCurrent(value)
+	if len(words) > 0 && words[0] == "sumup" {
+		words = words[1:]
+	}
+
+	if len(words) == 0 {
+		names := slices.Concat(builtins, m.aliases, commandNames(m.root))
+		sort.Strings(names)
+		return names, ""
+	}
+	if words[0] == "use" {
+		if len(words) > 1 {
+			return nil, ""
+		}
+		return m.flagValues("merchant-code")
+	}
+
+	cmd := m.root
+	flags := slices.Clone(m.root.Flags)
+	for _, word := range words {
+		if strings.HasPrefix(word, "-") {
+			continue
+		}
+		if sub := cmd.Command(word); sub != nil {
+			cmd = sub
+			flags = append(flags, sub.Flags...)
+		}
+	}
+
+	if name, ok := pendingFlag(flags, words[len(words)-1]); ok {
+		return m.flagValues(name)
+	}
+	if strings.HasPrefix(current, "-") {
+		names := make([]string, 0, len(flags))
+		for _, flag := range flags {
+			names = append(names, "--"+flag.Names()[0])
+		}
+		return names, ""
+	}
+	return commandNames(cmd), ""
+}
+
+// flagValues returns the cached values of a flag, or the flag as pending if
+// they still have to be loaded.
+func (m *editor) flagValues(name string) ([]string, string) {
+	if completion.FlagCompleter(name) == nil {
+		return nil, ""
+	}
+	values, ok := m.session.flagValues[name]
+	if !ok {
+		return nil, name
+	}
+	return values, ""
+}
+
+func (m *editor) loadFlagValues(name string) tea.Cmd {
+	completer := completion.FlagCompleter(name)
+	appCtx := m.session.appCtx
+	root := m.root
+	return func() tea.Msg {
+		suggestions, err := completer(m.ctx, appCtx, root)
+		values := make([]string, 0, len(suggestions))
+		if err == nil {
+			for _, suggestion := range suggestions {
+				values = append(values, suggestion.Value)
+			}
+		}
+		return flagValuesMsg{flag: name, values: values}
+	}
+}
+
+// splitCurrent splits a line into the complete words and the word being
+// typed, which is empty after a trailing space.
+func splitCurrent(value string) ([]string, string) {
+	words := strings.Fields(value)
+	if len(words) == 0 || strings.HasSuffix(value, " ") {
+		return words, ""
+	}
+	return words[:len(words)-1], words[len(words)-1]
+}
+
+func commandNames(cmd *cli.Command) []string {
+	var names []string
+	for _, sub := range cmd.Commands {
+		if sub.Hidden {
+			continue
+		}
+		names = append(names, sub.Name)
+	}
+	return names
+}
+
+// pendingFlag reports whether arg is a flag that still awaits its value.
+func pendingFlag(flags []cli.Flag, arg string) (string, bool) {
+	if !strings.HasPrefix(arg, "-") || strings.Contains(arg, "=") {
+		return "", false
+	}
+	name := strings.TrimLeft(arg, "-")
+	for _, flag := range flags {
+		if !slices.Contains(flag.Names(), name) {
+			continue
+		}
+		docFlag, ok := flag.(cli.DocGenerationFlag)
+		if !ok || !docFlag.TakesValue() {
+			return "", false
+		}
+		return flag.Names()[0], true
+	}
+	return "", false
+}
@@ -0,0 +1,354 @@
+package shell
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"golang.org/x/term"
+
+	"github.com/sumup/sumup-go/merchants"
+
+	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/audit"
+	"github.com/sumup/sumup-cli/internal/commands/alias"
+	"github.com/sumup/sumup-cli/internal/commands/plugin"
+	"github.com/sumup/sumup-cli/internal/config"
+	"github.com/sumup/sumup-cli/internal/display/message"
+)
+
+const (
+	historyFile  = "shell_history"
+	historyLimit = 1000
+)
+
+// NewCommand returns the shell command. The commands function builds a fresh
+// command tree for every line, so flag values never leak from one command to
+// the next.
+func NewCommand(commands func() []*cli.Command) *cli.Command {
+	return &cli.Command{
+		Name:  "shell",
+		Usage: "Start an interactive session that runs commands without the sumup prefix.",
+		Description: `Commands run in the shell share the API client of the session. Besides all
+sumup commands, the shell understands:
+
+   use <merchant-code>   switch the merchant for this session only
+   use                   show the merchant of this session
+   exit                  leave the shell (or press ctrl+d)`,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			appCtx, err := app.GetAppContext(cmd)
+			if err != nil {
+				return err
+			}
+			s := &session{
+				appCtx:   appCtx,
+				commands: commands,
+			}
+			return s.run(ctx)
+		},
+	}
+}
+
+type session struct {
+	appCtx   *app.Context
+	commands func() []*cli.Command
+	history  []string
+	// merchantNames caches the names of merchants switched to with use.
+	merchantNames map[string]string
+	// flagValues caches completion values of flags for the session.
+	flagValues map[string][]string
+	// loadingFlags holds the flags whose values the current editor is
+	// loading.
+	loadingFlags map[string]bool
+}
+
+func (s *session) run(ctx context.Context) error {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return s.runScript(ctx)
+	}
+
+	s.history = loadHistory()
+	message.Notify("Type 'exit' or press ctrl+d to leave the shell.")
+	for {
+		line, err := readLine(ctx, s)
+		if errors.Is(err, errExit) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.record(line)
+		if done := s.execute(ctx, line); done {
+			return nil
+		}
+	}
+}
+
+// runScript executes commands read line by line from a non-interactive
+// stdin, which makes the shell usable with pipes and here-documents.
+func (s *session) runScript(ctx context.Context) error {
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		if done := s.execute(ctx, scanner.Text()); done {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read commands: %w", err)
+	}
+	return nil
+}
+
+// execute runs a single line and reports whether the shell should exit.
+func (s *session) execute(ctx context.Context, line string) bool {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return false
+	}
+
+	args, err := alias.SplitArgs(line)
+	if err != nil {
+		message.Error("%v", err)
+		return false
+	}
+	if args[0] == "sumup" {
+		args = args[1:]
+		if len(args) == 0 {
+			return false
+		}
+	}
+
+	switch args[0] {
+	case "exit", "quit":
+		return true
+	case "use":
+		if err := s.use(ctx, args[1:]); err != nil {
+			message.Error("%v", err)
+		}
+		return false
+	}
+
+	// The command runs with a fresh context: urfave/cli attaches a command
+	// run with the context of another command to it as a subcommand, which
+	// would leak flags and metadata into the shell command. ctrl+c only
+	// cancels the running command.
+	runCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	root := s.newRoot()
+	root.Metadata[app.ArgsKey] = args
+	args, err = alias.Expand(root, append([]string{root.Name}, args...))
+	if err != nil {
+		message.Error("%v", err)
+		return false
+	}
+	if err := root.Run(runCtx, args); err != nil {
+		message.Error("%v", err)
+	}
+	return false
+}
+
+// newRoot builds the command tree for a single line. The global output flags
+// may be repeated per line and override the session settings for that line.
+func (s *session) newRoot() *cli.Command {
+	pluginArgs := 1
+	return &cli.Command{
+		Name:  "sumup",
+		Usage: "Command line tool for the SumUp API",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "Output results in JSON format instead of human-readable tables.",
+			},
+			&cli.BoolFlag{
+				Name:  "exact-timestamps",
+				Usage: "Show timestamp fields using the exact local time instead of relative strings.",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Print the API request a mutating command would send without sending it.",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Skip confirmation prompts for destructive commands.",
+			},
+		},
+		Metadata: map[string]any{},
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			appCtx := *s.appCtx
+			if cmd.IsSet("json") {
+				appCtx.JSONOutput = cmd.Bool("json")
+			}
+			if cmd.IsSet("exact-timestamps") {
+				appCtx.ExactTimestamps = cmd.Bool("exact-timestamps")
+			}
+			if cmd.IsSet("dry-run") {
+				appCtx.DryRun = cmd.Bool("dry-run")
+			}
+			if cmd.IsSet("yes") {
+				appCtx.AssumeYes = cmd.Bool("yes")
+			}
+			cmd.Root().Metadata[app.ContextKey] = &appCtx
+			return ctx, nil
+		},
+		Commands:     s.sessionCommands(),
+		StopOnNthArg: &pluginArgs,
+		Action:       plugin.Run,
+		// Errors are reported by the shell, which must keep running even if
+		// a command asks for a non-zero exit code.
+		ExitErrHandler: func(context.Context, *cli.Command, error) {},
+	}
+}
+
+// sessionCommands returns the commands available in the shell, which are
+// all commands except the shell itself.
+func (s *session) sessionCommands() []*cli.Command {
+	return slices.DeleteFunc(s.commands(), func(cmd *cli.Command) bool {
+		return cmd.Name == "shell"
+	})
+}
+
+// use switches the merchant for the rest of the session. The merchant code is
+// exported as SUMUP_MERCHANT_CODE, which every merchant-code flag and plugin
+// reads, so the saved context stays untouched.
+func (s *session) use(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		merchantCode := s.merchantCode()
+		if merchantCode == "" {
+			message.Notify("No merchant selected. Use 'use <merchant-code>' to select one.")
+			return nil
+		}
+		message.Notify("Current merchant: %s", s.merchantLabel(merchantCode))
+		return nil
+	}
+	if len(args) > 1 {
+		return errors.New("usage: use <merchant-code>")
+	}
+
+	merchantCode := args[0]
+	merchant, err := s.appCtx.Client.Merchants.Get(ctx, merchantCode, merchants.GetMerchantParams{})
+	if err != nil {
+		return fmt.Errorf("get merchant %s: %w", merchantCode, err)
+	}
+	if merchant.Company != nil && merchant.Company.Name != nil {
+		if s.merchantNames == nil {
+			s.merchantNames = map[string]string{}
+		}
+		s.merchantNames[merchantCode] = *merchant.Company.Name
+	}
+
+	if err := os.Setenv("SUMUP_MERCHANT_CODE", merchantCode); err != nil {
+		return fmt.Errorf("switch merchant: %w", err)
+	}
+	message.Success("Using merchant %s for this session.", s.merchantLabel(merchantCode))
+	return nil
+}
+
+// merchantCode returns the merchant commands in this session default to.
+func (s *session) merchantCode() string {
+	if merchantCode := os.Getenv("SUMUP_MERCHANT_CODE"); merchantCode != "" {
+		return merchantCode
+	}
+	merchantCode, err := config.GetCurrentMerchantCode()
+	if err != nil {
+		return ""
+	}
+	return merchantCode
+}
+
+func (s *session) merchantLabel(merchantCode string) string {
+	if name, ok := s.merchantNames[merchantCode]; ok {
+		return fmt.Sprintf("%s (%s)", name, merchantCode)
+	}
+	return merchantCode
+}
+
+// prompt shows the API host when it is not production and the merchant of
+// the session.
+func (s *session) prompt() string {
+	var b strings.Builder
+	b.WriteString("sumup")
+	if !s.appCtx.IsProduction() {
+		host := s.appCtx.BaseURL
+		if u, err := url.Parse(s.appCtx.BaseURL); err == nil && u.Host != "" {
+			host = u.Host
+		}
+		fmt.Fprintf(&b, " [%s]", host)
+	}
+	if merchantCode := s.merchantCode(); merchantCode != "" {
+		fmt.Fprintf(&b, " (%s)", merchantCode)
+	}
+	b.WriteString("> ")
+	return b.String()
+}
+
+// record adds the line to the history. Lines carrying secrets are kept for
+// the session but never written to disk.
+func (s *session) record(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" || (len(s.history) > 0 && s.history[len(s.history)-1] == line) {
+		return
+	}
+	s.history = append(s.history, line)
+	args, err := alias.SplitArgs(line)
+	if err != nil || !slices.Equal(audit.SanitizeArgs(args), args) {
+		return
+	}
+	appendHistory(line)
+}
+
+func historyPath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, historyFile), nil
+}
+
+// loadHistory reads the most recent lines of the history file. A missing or
+// unreadable file starts the session with an empty history.
+func loadHistory() []string {
+	path, err := historyPath()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) > historyLimit {
+		lines = lines[len(lines)-historyLimit:]
+	}
+	return slices.DeleteFunc(lines, func(line string) bool { return line == "" })
+}
+
+// appendHistory adds the line to the history file, keeping only the most
+// recent historyLimit lines.
+func appendHistory(line string) {
+	path, err := historyPath()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	var lines []string
+	if data, err := os.ReadFile(path); err == nil {
+		lines = strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	}
+	lines = append(slices.DeleteFunc(lines, func(line string) bool { return line == "" }), line)
+	if len(lines) > historyLimit {
+		lines = lines[len(lines)-historyLimit:]
+	}
+	_ = os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600)
+}
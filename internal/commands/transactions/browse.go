@@ -0,0 +1,786 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/urfave/cli/v3"
+	"golang.org/x/term"
+
+	"github.com/sumup/sumup-go/receipts"
+	"github.com/sumup/sumup-go/transactions"
+
+	"github.com/sumup/sumup-cli/internal/app"
+	receiptscmd "github.com/sumup/sumup-cli/internal/commands/receipts"
+	"github.com/sumup/sumup-cli/internal/commands/util"
+	"github.com/sumup/sumup-cli/internal/currency"
+	"github.com/sumup/sumup-cli/internal/display"
+)
+
+const (
+	debounceDelay = 500 * time.Millisecond
+	// browsePageSize is the number of transactions requested per page.
+	browsePageSize = 50
+	// prefetchRows is how close to the end of the loaded rows the cursor may
+	// get before the next page is requested.
+	prefetchRows = 10
+	// maxFillPages bounds the pages loaded in a row only to fill the table,
+	// so a filter matching nothing does not page through the whole history.
+	maxFillPages = 10
+)
+
+// browseColumns are the table columns; the keys 1 to 6 sort by them.
+var browseColumns = []string{"ID", "Code", "Amount", "Status", "Payment Type", "Created At"}
+
+// transactionFilter holds the parsed filter expression. Statuses and payment
+// types are sent to the API, the amount range is applied to loaded rows.
+type transactionFilter struct {
+	statuses     []string
+	paymentTypes []string
+	minAmount    *float64
+	maxAmount    *float64
+}
+
+type pageMsg struct {
+	// generation identifies the filter the page was requested for, so pages
+	// of a previous filter are dropped.
+	generation int
+	items      []transactions.TransactionHistory
+	next       *transactions.ListTransactionsV21Params
+	err        error
+}
+
+// filterDebounceMsg is sent after the debounce delay to apply the filter.
+type filterDebounceMsg struct{}
+
+type browseDetailsMsg struct {
+	id          string
+	transaction *transactions.TransactionFull
+	receipt     *receipts.Receipt
+	err         error
+	receiptErr  error
+}
+
+type browser struct {
+	ctx          context.Context
+	appCtx       *app.Context
+	merchantCode string
+	width        int
+	height       int
+
+	table table.Model
+	// All loaded transactions in API order
+	items []transactions.TransactionHistory
+	// Loaded transactions after the amount filter and sorting
+	displayed []transactions.TransactionHistory
+	// Parameters of the next page, nil if everything has been loaded
+	next    *transactions.ListTransactionsV21Params
+	loading bool
+	err     error
+	// Pages loaded in a row only because the rows did not fill the table
+	fillPages int
+
+	filter      transactionFilter
+	filterInput textinput.Model
+	filtering   bool
+	filterErr   error
+	// Whether a filter change is pending (debouncing)
+	filterPending bool
+	// Last applied filter expression to avoid duplicate requests
+	lastFilter string
+	generation int
+
+	// Sort column index, -1 keeps the API order
+	sortColumn int
+	sortDesc   bool
+
+	showDetails bool
+	details     viewport.Model
+	detailsID   string
+	detailsCode string
+
+	status string
+}
+
+func browseTransactions(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+	if appCtx.JSONOutput || !term.IsTerminal(int(os.Stdout.Fd())) || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return errors.New("transactions browse needs an interactive terminal, use transactions list instead")
+	}
+
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		return err
+	}
+
+	expression := strings.Join(slices.Concat(
+		prefixed("status:", cmd.StringSlice("status")),
+		prefixed("type:", cmd.StringSlice("payment-type")),
+	), " ")
+	filter, err := parseFilter(expression)
+	if err != nil {
+		return err
+	}
+
+	filterInput := textinput.New()
+	filterInput.Placeholder = "status:successful type:pos amount:10..50"
+	filterInput.Prompt = ""
+	filterInput.CharLimit = 100
+	filterInput.SetValue(expression)
+
+	t := table.New(table.WithFocused(true))
+	styles := table.DefaultStyles()
+	styles.Header = styles.Header.Bold(true).Foreground(lipgloss.Color("#8E8E8E"))
+	styles.Selected = styles.Selected.Foreground(display.SumUpPink)
+	t.SetStyles(styles)
+
+	b := browser{
+		ctx:          ctx,
+		appCtx:       appCtx,
+		merchantCode: merchantCode,
+		table:        t,
+		filter:       filter,
+		filterInput:  filterInput,
+		lastFilter:   expression,
+		sortColumn:   -1,
+		details:      viewport.New(0, 0),
+	}
+	if _, err := tea.NewProgram(b, tea.WithAltScreen(), tea.WithContext(ctx)).Run(); err != nil {
+		return fmt.Errorf("run transaction browser: %w", err)
+	}
+	return nil
+}
+
+func prefixed(prefix string, values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		out = append(out, prefix+value)
+	}
+	return out
+}
+
+func (b browser) Init() tea.Cmd {
+	return b.loadPage(b.firstPage(), b.generation)
+}
+
+func (b browser) firstPage() transactions.ListTransactionsV21Params {
+	limit := browsePageSize
+	order := "desc"
+	return transactions.ListTransactionsV21Params{
+		Limit:        &limit,
+		Order:        &order,
+		Statuses:     b.filter.statuses,
+		PaymentTypes: b.filter.paymentTypes,
+	}
+}
+
+// loadPage requests a page of transactions.
+func (b browser) loadPage(params transactions.ListTransactionsV21Params, generation int) tea.Cmd {
+	return func() tea.Msg {
+		response, err := b.appCtx.Client.Transactions.List(b.ctx, b.merchantCode, params)
+		if err != nil {
+			return pageMsg{generation: generation, err: err}
+		}
+		msg := pageMsg{generation: generation, items: response.Items}
+		if next, ok := NextPageParams(params, response.Links); ok && len(response.Items) > 0 {
+			msg.next = &next
+		}
+		return msg
+	}
+}
+
+// loadMore requests the next page if the cursor is close to the end of the
+// loaded rows or the filtered rows do not fill the table yet.
+func (b *browser) loadMore() tea.Cmd {
+	if b.loading || b.next == nil {
+		return nil
+	}
+	fill := len(b.displayed) < b.table.Height()
+	if !fill && b.table.Cursor() < len(b.displayed)-prefetchRows {
+		return nil
+	}
+	if fill {
+		if b.fillPages >= maxFillPages {
+			return nil
+		}
+		b.fillPages++
+	} else {
+		b.fillPages = 0
+	}
+	b.loading = true
+	return b.loadPage(*b.next, b.generation)
+}
+
+// debounce returns a command that waits for the debounce delay before sending a filterDebounceMsg
+func debounce() tea.Cmd {
+	return tea.Tick(debounceDelay, func(t time.Time) tea.Msg {
+		return filterDebounceMsg{}
+	})
+}
+
+func (b browser) loadDetails(transactionID string) tea.Cmd {
+	return func() tea.Msg {
+		transaction, err := b.appCtx.Client.Transactions.Get(b.ctx, b.merchantCode, transactions.GetTransactionV21Params{
+			ID: &transactionID,
+		})
+		if err != nil {
+			return browseDetailsMsg{id: transactionID, err: err}
+		}
+		receipt, receiptErr := b.appCtx.Client.Receipts.Get(b.ctx, transactionID, receipts.GetReceiptParams{
+			Mid: b.merchantCode,
+		})
+		return browseDetailsMsg{id: transactionID, transaction: transaction, receipt: receipt, receiptErr: receiptErr}
+	}
+}
+
+func (b browser) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		b.width, b.height = msg.Width, msg.Height
+		b.resize()
+		return b, b.loadMore()
+
+	case pageMsg:
+		if msg.generation != b.generation {
+			return b, nil
+		}
+		b.loading = false
+		if msg.err != nil {
+			b.err = msg.err
+			return b, nil
+		}
+		b.err = nil
+		b.items = append(b.items, msg.items...)
+		b.next = msg.next
+		b.refreshRows()
+		return b, b.loadMore()
+
+	case filterDebounceMsg:
+		if !b.filterPending {
+			return b, nil
+		}
+		b.filterPending = false
+		return b, b.applyFilter()
+
+	case browseDetailsMsg:
+		// Details of a transaction that is no longer shown arrive late.
+		if !b.showDetails || msg.id != b.detailsID {
+			return b, nil
+		}
+		if msg.err != nil {
+			b.showDetails = false
+			b.status = fmt.Sprintf("Failed to load transaction: %v", msg.err)
+			return b, nil
+		}
+		var content strings.Builder
+		content.WriteString(display.DataListString(DetailAttributes(b.appCtx, msg.transaction)))
+		content.WriteString("\nReceipt\n")
+		if msg.receiptErr != nil {
+			content.WriteString(fmt.Sprintf("Receipt unavailable: %v\n", msg.receiptErr))
+		} else {
+			content.WriteString(receiptscmd.Format(msg.receipt))
+		}
+		b.details.SetContent(content.String())
+		b.details.GotoTop()
+		return b, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return b, tea.Quit
+		}
+		if b.showDetails {
+			return b.updateDetails(msg)
+		}
+		if b.filtering {
+			return b.updateFilter(msg)
+		}
+		return b.updateTable(msg)
+	}
+
+	return b, nil
+}
+
+func (b browser) updateTable(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	b.status = ""
+	switch key := msg.String(); key {
+	case "q", "esc":
+		return b, tea.Quit
+	case "/":
+		b.filtering = true
+		b.filterInput.CursorEnd()
+		return b, b.filterInput.Focus()
+	case "enter":
+		tx, ok := b.selected()
+		if !ok || tx.ID == nil {
+			return b, nil
+		}
+		b.showDetails = true
+		b.detailsID = *tx.ID
+		b.detailsCode = util.StringOrDefault(tx.TransactionCode, "")
+		b.details.SetContent("Loading transaction...")
+		return b, b.loadDetails(*tx.ID)
+	case "i":
+		if tx, ok := b.selected(); ok {
+			b.copy("ID", util.StringOrDefault(tx.ID, ""))
+		}
+		return b, nil
+	case "c":
+		if tx, ok := b.selected(); ok {
+			b.copy("code", util.StringOrDefault(tx.TransactionCode, ""))
+		}
+		return b, nil
+	case "1", "2", "3", "4", "5", "6":
+		column := int(key[0] - '1')
+		if b.sortColumn == column {
+			b.sortDesc = !b.sortDesc
+		} else {
+			b.sortColumn = column
+			b.sortDesc = false
+		}
+		b.refreshRows()
+		return b, nil
+	case "0":
+		b.sortColumn = -1
+		b.refreshRows()
+		return b, nil
+	case "m":
+		b.fillPages = 0
+		return b, b.loadMore()
+	}
+
+	var cmd tea.Cmd
+	b.table, cmd = b.table.Update(msg)
+	return b, tea.Batch(cmd, b.loadMore())
+}
+
+func (b browser) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "esc":
+		b.filtering = false
+		b.filterInput.Blur()
+		if b.filterPending {
+			b.filterPending = false
+			return b, b.applyFilter()
+		}
+		return b, nil
+	}
+
+	oldValue := b.filterInput.Value()
+	var cmd tea.Cmd
+	b.filterInput, cmd = b.filterInput.Update(msg)
+	if b.filterInput.Value() != oldValue {
+		b.filterPending = true
+		return b, tea.Batch(cmd, debounce())
+	}
+	return b, cmd
+}
+
+func (b browser) updateDetails(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "enter":
+		b.showDetails = false
+		return b, nil
+	case "i":
+		b.copy("ID", b.detailsID)
+		return b, nil
+	case "c":
+		b.copy("code", b.detailsCode)
+		return b, nil
+	}
+	var cmd tea.Cmd
+	b.details, cmd = b.details.Update(msg)
+	return b, cmd
+}
+
+// applyFilter parses the filter expression. Changes to statuses or payment
+// types reload the transactions from the first page, changes to the amount
+// range only filter the loaded rows.
+func (b *browser) applyFilter() tea.Cmd {
+	expression := strings.TrimSpace(b.filterInput.Value())
+	if expression == b.lastFilter {
+		return nil
+	}
+	filter, err := parseFilter(expression)
+	if err != nil {
+		b.filterErr = err
+		return nil
+	}
+	b.filterErr = nil
+	b.lastFilter = expression
+	b.fillPages = 0
+
+	reload := !slices.Equal(filter.statuses, b.filter.statuses) || !slices.Equal(filter.paymentTypes, b.filter.paymentTypes)
+	b.filter = filter
+	if !reload {
+		b.refreshRows()
+		return b.loadMore()
+	}
+
+	b.generation++
+	b.items = nil
+	b.next = nil
+	b.err = nil
+	b.loading = true
+	b.refreshRows()
+	return b.loadPage(b.firstPage(), b.generation)
+}
+
+func (b *browser) selected() (transactions.TransactionHistory, bool) {
+	cursor := b.table.Cursor()
+	if cursor < 0 || cursor >= len(b.displayed) {
+		return transactions.TransactionHistory{}, false
+	}
+	return b.displayed[cursor], true
+}
+
+func (b *browser) copy(label, value string) {
+	if value == "" {
+		b.status = fmt.Sprintf("The transaction has no %s.", label)
+		return
+	}
+	if err := copyToClipboard(value); err != nil {
+		b.status = fmt.Sprintf("Failed to copy %s: %v", label, err)
+		return
+	}
+	b.status = fmt.Sprintf("Copied %s %s to the clipboard.", label, value)
+}
+
+// copyToClipboard uses the system clipboard and falls back to the OSC 52
+// escape sequence, which also works over SSH in most terminals.
+func copyToClipboard(value string) error {
+	if err := clipboard.WriteAll(value); err == nil {
+		return nil
+	}
+	_, err := osc52.New(value).WriteTo(os.Stderr)
+	return err
+}
+
+// refreshRows applies the amount filter and the sort order to the loaded
+// transactions and updates the table.
+func (b *browser) refreshRows() {
+	b.displayed = b.displayed[:0]
+	for _, tx := range b.items {
+		if b.filter.matchesAmount(tx) {
+			b.displayed = append(b.displayed, tx)
+		}
+	}
+	if b.sortColumn >= 0 {
+		slices.SortStableFunc(b.displayed, func(x, y transactions.TransactionHistory) int {
+			result := compareTransactions(x, y, b.sortColumn)
+			if b.sortDesc {
+				return -result
+			}
+			return result
+		})
+	}
+
+	rows := make([]table.Row, 0, len(b.displayed))
+	for _, tx := range b.displayed {
+		rows = append(rows, table.Row{
+			util.StringOrDefault(tx.ID, "-"),
+			util.StringOrDefault(tx.TransactionCode, "-"),
+			currency.FormatPointers(tx.Amount, tx.Currency),
+			transactionHistoryStatus(tx.Status),
+			transactionHistoryPaymentType(tx.PaymentType),
+			util.TimeOrDash(b.appCtx, tx.Timestamp),
+		})
+	}
+	b.table.SetRows(rows)
+	// The table moves the cursor to -1 while it has no rows.
+	if cursor := b.table.Cursor(); cursor < 0 || cursor >= len(rows) {
+		b.table.SetCursor(max(0, min(cursor, len(rows)-1)))
+	}
+	b.resize()
+}
+
+func compareTransactions(x, y transactions.TransactionHistory, column int) int {
+	switch column {
+	case 0:
+		return strings.Compare(util.StringOrDefault(x.ID, ""), util.StringOrDefault(y.ID, ""))
+	case 1:
+		return strings.Compare(util.StringOrDefault(x.TransactionCode, ""), util.StringOrDefault(y.TransactionCode, ""))
+	case 2:
+		return compareFloat(x.Amount, y.Amount)
+	case 3:
+		return strings.Compare(transactionHistoryStatus(x.Status), transactionHistoryStatus(y.Status))
+	case 4:
+		return strings.Compare(transactionHistoryPaymentType(x.PaymentType), transactionHistoryPaymentType(y.PaymentType))
+	default:
+		switch {
+		case x.Timestamp == nil || y.Timestamp == nil:
+			return 0
+		default:
+			return x.Timestamp.Compare(*y.Timestamp)
+		}
+	}
+}
+
+func compareFloat(x, y *float32) int {
+	var a, b float32
+	if x != nil {
+		a = *x
+	}
+	if y != nil {
+		b = *y
+	}
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// resize fits the table columns to the window.
+func (b *browser) resize() {
+	if b.width == 0 {
+		return
+	}
+	// Title, filter, status and help lines plus the table header.
+	b.table.SetHeight(max(3, b.height-6))
+	b.table.SetWidth(b.width)
+	widths := []int{20, 12, 14, 12, 14, 20}
+	total := 0
+	for _, width := range widths {
+		total += width + 2
+	}
+	if extra := b.width - total; extra > 0 {
+		widths[0] += extra / 2
+		widths[len(widths)-1] += extra - extra/2
+	}
+
+	columns := make([]table.Column, len(browseColumns))
+	for i, title := range browseColumns {
+		if i == b.sortColumn {
+			if b.sortDesc {
+				title += " ↓"
+			} else {
+				title += " ↑"
+			}
+		}
+		columns[i] = table.Column{Title: title, Width: widths[i]}
+	}
+	b.table.SetColumns(columns)
+	b.details.Width = b.width
+	b.details.Height = max(1, b.height-2)
+}
+
+func (b browser) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("6"))
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+
+	if b.showDetails {
+		return b.details.View() + "\n" + b.statusLine(helpStyle, "↑/↓: scroll | i: copy ID | c: copy code | esc: back")
+	}
+
+	var s strings.Builder
+	title := fmt.Sprintf("Transactions · %s", b.merchantCode)
+	s.WriteString(titleStyle.Render(title))
+	count := fmt.Sprintf(" · %d shown", len(b.displayed))
+	switch {
+	case b.loading:
+		count += ", loading..."
+	case b.next != nil && b.fillPages >= maxFillPages:
+		count += fmt.Sprintf(", no more matches in the last %d pages, m: load more", maxFillPages)
+	case b.next != nil:
+		count += ", more available"
+	}
+	s.WriteString(helpStyle.Render(count))
+	s.WriteString("\n")
+
+	if b.filtering || b.filterInput.Value() != "" {
+		s.WriteString("Filter: ")
+		s.WriteString(b.filterInput.View())
+		if b.filterErr != nil {
+			s.WriteString(" " + errorStyle.Render(b.filterErr.Error()))
+		}
+	}
+	s.WriteString("\n")
+
+	if b.err != nil {
+		s.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", b.err)))
+		s.WriteString("\n")
+	}
+	s.WriteString(b.table.View())
+	s.WriteString("\n")
+
+	if b.filtering {
+		s.WriteString(b.statusLine(helpStyle, "status:<s,...> type:<t,...> amount:<min>..<max> | enter/esc: done"))
+	} else {
+		s.WriteString(b.statusLine(helpStyle, "↑/↓: navigate | enter: details | /: filter | 1-6: sort, 0: reset | i: copy ID | c: copy code | q: quit"))
+	}
+	return s.String()
+}
+
+func (b browser) statusLine(helpStyle lipgloss.Style, help string) string {
+	if b.status != "" {
+		return b.status + "\n" + helpStyle.Render(help)
+	}
+	return "\n" + helpStyle.Render(help)
+}
+
+// parseFilter parses a filter expression made of space separated terms:
+// status:<values>, type:<values> and amount:<min>..<max>, where values are
+// comma separated and either bound of the amount range may be omitted. Bare
+// terms are matched against known statuses and payment types, and amount
+// ranges.
+func parseFilter(expression string) (transactionFilter, error) {
+	var filter transactionFilter
+	for _, term := range strings.Fields(expression) {
+		key, value, found := strings.Cut(term, ":")
+		if !found {
+			key, value = classifyTerm(term), term
+		}
+		switch strings.ToLower(key) {
+		case "status":
+			for _, status := range strings.Split(value, ",") {
+				normalized, err := matchEnum(status, transactionStatuses)
+				if err != nil {
+					return transactionFilter{}, fmt.Errorf("unknown status %q", status)
+				}
+				filter.statuses = appendUnique(filter.statuses, normalized)
+			}
+		case "type", "payment-type":
+			for _, paymentType := range strings.Split(value, ",") {
+				normalized, err := matchEnum(paymentType, paymentTypes)
+				if err != nil {
+					return transactionFilter{}, fmt.Errorf("unknown payment type %q", paymentType)
+				}
+				filter.paymentTypes = appendUnique(filter.paymentTypes, normalized)
+			}
+		case "amount":
+			minAmount, maxAmount, err := parseAmountRange(value)
+			if err != nil {
+				return transactionFilter{}, err
+			}
+			filter.minAmount, filter.maxAmount = minAmount, maxAmount
+		default:
+			return transactionFilter{}, fmt.Errorf("unknown filter %q", term)
+		}
+	}
+	return filter, nil
+}
+
+var transactionStatuses = []string{
+	string(transactions.TransactionHistoryStatusSuccessful),
+	string(transactions.TransactionHistoryStatusPending),
+	string(transactions.TransactionHistoryStatusFailed),
+	string(transactions.TransactionHistoryStatusCancelled),
+}
+
+var paymentTypes = []string{
+	string(transactions.TransactionHistoryPaymentTypePos),
+	string(transactions.TransactionHistoryPaymentTypeEcom),
+	string(transactions.TransactionHistoryPaymentTypeRecurring),
+	string(transactions.TransactionHistoryPaymentTypeBoleto),
+}
+
+// classifyTerm guesses the filter a bare term belongs to.
+func classifyTerm(term string) string {
+	if _, err := matchEnum(term, transactionStatuses); err == nil {
+		return "status"
+	}
+	if _, err := matchEnum(term, paymentTypes); err == nil {
+		return "type"
+	}
+	if _, _, err := parseAmountRange(term); err == nil {
+		return "amount"
+	}
+	return term
+}
+
+// matchEnum matches a value case-insensitively, accepting unique prefixes
+// so that filters apply while the user is still typing.
+func matchEnum(value string, values []string) (string, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	var matches []string
+	for _, candidate := range values {
+		if candidate == value {
+			return candidate, nil
+		}
+		if value != "" && strings.HasPrefix(candidate, value) {
+			matches = append(matches, candidate)
+		}
+	}
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+	return "", fmt.Errorf("no match for %q", value)
+}
+
+// parseAmountRange parses "10..50", "10..", "..50" or a single amount.
+func parseAmountRange(value string) (*float64, *float64, error) {
+	lower, upper, isRange := strings.Cut(value, "..")
+	if !isRange {
+		upper = lower
+	}
+	minAmount, err := parseOptionalAmount(lower)
+	if err != nil {
+		return nil, nil, err
+	}
+	maxAmount, err := parseOptionalAmount(upper)
+	if err != nil {
+		return nil, nil, err
+	}
+	if minAmount == nil && maxAmount == nil {
+		return nil, nil, fmt.Errorf("invalid amount range %q", value)
+	}
+	if minAmount != nil && maxAmount != nil && *minAmount > *maxAmount {
+		return nil, nil, fmt.Errorf("invalid amount range %q, minimum exceeds maximum", value)
+	}
+	return minAmount, maxAmount, nil
+}
+
+func parseOptionalAmount(value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount %q", value)
+	}
+	return &amount, nil
+}
+
+func appendUnique(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
+
+func (f transactionFilter) matchesAmount(tx transactions.TransactionHistory) bool {
+	if f.minAmount == nil && f.maxAmount == nil {
+		return true
+	}
+	if tx.Amount == nil {
+		return false
+	}
+	amount := float64(*tx.Amount)
+	if f.minAmount != nil && amount < *f.minAmount {
+		return false
+	}
+	if f.maxAmount != nil && amount > *f.maxAmount {
+		return false
+	}
+	return true
+}
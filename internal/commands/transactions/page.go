@@ -0,0 +1,51 @@
+package transactions
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/sumup/sumup-go/transactions"
+)
+
+// NextPageParams returns the parameters for the page that follows a list
+// response, taken from the cursors of its next link. Filters other than the
+// cursors are kept. It returns false if there are no more pages.
+func NextPageParams(params transactions.ListTransactionsV21Params, links []transactions.Link) (transactions.ListTransactionsV21Params, bool) {
+	for _, link := range links {
+		if link.Rel == nil || *link.Rel != "next" || link.Href == nil {
+			continue
+		}
+		href, err := url.Parse(*link.Href)
+		if err != nil {
+			return params, false
+		}
+		query := href.Query()
+		if len(query) == 0 {
+			query, err = url.ParseQuery(*link.Href)
+			if err != nil {
+				return params, false
+			}
+		}
+
+		next := params
+		found := false
+		if value := query.Get("newest_ref"); value != "" {
+			next.NewestRef, next.NewestTime = &value, nil
+			found = true
+		}
+		if value, err := time.Parse(time.RFC3339, query.Get("newest_time")); err == nil {
+			next.NewestTime = &value
+			found = true
+		}
+		if value := query.Get("oldest_ref"); value != "" {
+			next.OldestRef, next.OldestTime = &value, nil
+			found = true
+		}
+		if value, err := time.Parse(time.RFC3339, query.Get("oldest_time")); err == nil {
+			next.OldestTime = &value
+			found = true
+		}
+		return next, found
+	}
+	return params, false
+}
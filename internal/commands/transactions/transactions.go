@@ -3,6 +3,7 @@ package transactions
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -12,9 +13,11 @@ import (
 
 	"github.com/sumup/sumup-cli/internal/app"
 	"github.com/sumup/sumup-cli/internal/commands/util"
+	"github.com/sumup/sumup-cli/internal/completion"
 	"github.com/sumup/sumup-cli/internal/currency"
 	"github.com/sumup/sumup-cli/internal/display"
 	"github.com/sumup/sumup-cli/internal/display/attribute"
+	"github.com/sumup/sumup-cli/internal/display/message"
 )
 
 func NewCommand() *cli.Command {
@@ -26,7 +29,21 @@ func NewCommand() *cli.Command {
 				Name:   "list",
 				Usage:  "List transactions for a merchant.",
 				Action: listTransactions,
-				Flags: []cli.Flag{
+				ShellComplete: completion.Complete(nil, map[string]completion.Completer{
+					"status": completion.Values(
+						string(transactions.TransactionHistoryStatusSuccessful),
+						string(transactions.TransactionHistoryStatusPending),
+						string(transactions.TransactionHistoryStatusFailed),
+						string(transactions.TransactionHistoryStatusCancelled),
+					),
+					"payment-type": completion.Values(
+						string(transactions.TransactionHistoryPaymentTypePos),
+						string(transactions.TransactionHistoryPaymentTypeEcom),
+						string(transactions.TransactionHistoryPaymentTypeRecurring),
+						string(transactions.TransactionHistoryPaymentTypeBoleto),
+					),
+				}),
+				Flags: append([]cli.Flag{
 					&cli.StringFlag{
 						Name:    "merchant-code",
 						Usage:   "Merchant code whose transactions should be listed. Falls back to context.",
@@ -80,6 +97,36 @@ func NewCommand() *cli.Command {
 						Name:  "user",
 						Usage: "Filter by user email. May be specified multiple times.",
 					},
+				}, util.FanOutFlags()...),
+			},
+			{
+				Name:  "browse",
+				Usage: "Browse transactions interactively with filters, sorting and details.",
+				Description: `Opens a table of transactions that loads further pages while scrolling.
+
+Press / to filter, e.g. "status:successful,failed type:pos amount:10..50".
+Status and payment type filters query the API, the amount range filters the
+loaded transactions. Press 1-6 to sort by a column, enter to show the details
+and receipt of a transaction, i or c to copy its ID or code.`,
+				Action: browseTransactions,
+				ShellComplete: completion.Complete(nil, map[string]completion.Completer{
+					"status":       completion.Values(transactionStatuses...),
+					"payment-type": completion.Values(paymentTypes...),
+				}),
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "merchant-code",
+						Usage:   "Merchant code whose transactions should be browsed. Falls back to context.",
+						Sources: cli.EnvVars("SUMUP_MERCHANT_CODE"),
+					},
+					&cli.StringSliceFlag{
+						Name:  "status",
+						Usage: "Initial status filter (repeatable).",
+					},
+					&cli.StringSliceFlag{
+						Name:  "payment-type",
+						Usage: "Initial payment type filter (repeatable).",
+					},
 				},
 			},
 			{
@@ -95,6 +142,23 @@ func NewCommand() *cli.Command {
 					},
 				},
 			},
+			{
+				Name:      "refund",
+				Usage:     "Refund a transaction in full or partially.",
+				Action:    refundTransaction,
+				ArgsUsage: "<transaction-id>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "merchant-code",
+						Usage:   "Merchant code that owns the transaction, recorded in the audit log. Falls back to context.",
+						Sources: cli.EnvVars("SUMUP_MERCHANT_CODE"),
+					},
+					&cli.Float64Flag{
+						Name:  "amount",
+						Usage: "Amount to refund. Omit to refund the full transaction amount.",
+					},
+				},
+			},
 		},
 	}
 }
@@ -105,11 +169,6 @@ func listTransactions(ctx context.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
-	if err != nil {
-		return err
-	}
-
 	params := transactions.ListTransactionsV21Params{}
 	if cmd.IsSet("limit") {
 		value := cmd.Int("limit")
@@ -159,6 +218,19 @@ func listTransactions(ctx context.Context, cmd *cli.Command) error {
 		params.Users = values
 	}
 
+	merchants, fanOut, err := util.FanOutMerchants(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	if fanOut {
+		return listMerchantsTransactions(ctx, cmd, appCtx, merchants, params)
+	}
+
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		return err
+	}
+
 	response, err := appCtx.Client.Transactions.List(ctx, merchantCode, params)
 	if err != nil {
 		return fmt.Errorf("list transactions: %w", err)
@@ -175,20 +247,55 @@ func listTransactions(ctx context.Context, cmd *cli.Command) error {
 
 	rows := make([][]string, 0, len(items))
 	for _, tx := range items {
-		rows = append(rows, []string{
-			util.StringOrDefault(tx.ID, "-"),
-			util.StringOrDefault(tx.TransactionCode, "-"),
-			currency.FormatPointers(tx.Amount, tx.Currency),
-			transactionHistoryStatus(tx.Status),
-			transactionHistoryPaymentType(tx.PaymentType),
-			util.TimeOrDash(appCtx, tx.Timestamp),
-		})
+		rows = append(rows, transactionRow(appCtx, tx))
 	}
 
-	display.RenderTable("Transactions", []string{"ID", "Code", "Amount", "Status", "Payment Type", "Created At"}, rows)
+	display.RenderTable("Transactions", transactionHeaders, rows)
 	return nil
 }
 
+var transactionHeaders = []string{"ID", "Code", "Amount", "Status", "Payment Type", "Created At"}
+
+// listMerchantsTransactions lists the transactions of many merchants with
+// the same filters.
+func listMerchantsTransactions(ctx context.Context, cmd *cli.Command, appCtx *app.Context, merchants []util.Merchant, params transactions.ListTransactionsV21Params) error {
+	results := util.FanOut(ctx, cmd, merchants, func(ctx context.Context, merchantCode string) ([]transactions.TransactionHistory, error) {
+		response, err := appCtx.Client.Transactions.List(ctx, merchantCode, params)
+		if err != nil {
+			return nil, fmt.Errorf("list transactions: %w", err)
+		}
+		return response.Items, nil
+	})
+
+	if appCtx.JSONOutput {
+		if err := display.PrintJSON(results); err != nil {
+			return err
+		}
+		return util.FanOutErrors(appCtx, results)
+	}
+
+	var rows [][]string
+	for _, result := range results {
+		for _, tx := range result.Result {
+			rows = append(rows, append([]string{result.MerchantCode}, transactionRow(appCtx, tx)...))
+		}
+	}
+
+	display.RenderTable("Transactions", append([]string{"Merchant"}, transactionHeaders...), rows)
+	return util.FanOutErrors(appCtx, results)
+}
+
+func transactionRow(appCtx *app.Context, tx transactions.TransactionHistory) []string {
+	return []string{
+		util.StringOrDefault(tx.ID, "-"),
+		util.StringOrDefault(tx.TransactionCode, "-"),
+		currency.FormatPointers(tx.Amount, tx.Currency),
+		transactionHistoryStatus(tx.Status),
+		transactionHistoryPaymentType(tx.PaymentType),
+		util.TimeOrDash(appCtx, tx.Timestamp),
+	}
+}
+
 func getTransaction(ctx context.Context, cmd *cli.Command) error {
 	appCtx, err := app.GetAppContext(cmd)
 	if err != nil {
@@ -221,6 +328,52 @@ func getTransaction(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
+func refundTransaction(ctx context.Context, cmd *cli.Command) error {
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	transactionID, err := util.RequireSingleArg(cmd, "transaction ID")
+	if err != nil {
+		return err
+	}
+
+	// Refunds are issued for the merchant of the API key, the merchant code
+	// only labels the audit log entry and may be unknown.
+	merchantCode, err := app.GetMerchantCode(cmd, "merchant-code")
+	if err != nil {
+		merchantCode = ""
+	}
+
+	body := transactions.RefundTransactionBody{}
+	if cmd.IsSet("amount") {
+		amount := cmd.Float64("amount")
+		if amount <= 0 {
+			return fmt.Errorf("amount must be greater than zero")
+		}
+		value := float32(amount)
+		body.Amount = &value
+	}
+
+	if appCtx.DryRun {
+		return util.DryRun(appCtx, http.MethodPost, "/v0.1/me/refund/"+transactionID, body)
+	}
+
+	err = appCtx.Client.Transactions.Refund(ctx, transactionID, body)
+	util.RecordAudit(cmd, merchantCode, transactionID, err)
+	if err != nil {
+		return fmt.Errorf("refund transaction: %w", err)
+	}
+
+	if appCtx.JSONOutput {
+		return display.PrintJSON(map[string]string{"status": "refunded"})
+	}
+
+	message.Success("Transaction refunded")
+	return nil
+}
+
 func transactionHistoryStatus(status *transactions.TransactionHistoryStatus) string {
 	if status == nil {
 		return "-"
@@ -236,6 +389,11 @@ func transactionHistoryPaymentType(paymentType *transactions.TransactionHistoryP
 }
 
 func renderTransactionDetails(appCtx *app.Context, transaction *transactions.TransactionFull) {
+	display.DataList(DetailAttributes(appCtx, transaction))
+}
+
+// DetailAttributes returns the attributes shown for a single transaction.
+func DetailAttributes(appCtx *app.Context, transaction *transactions.TransactionFull) []attribute.KeyValue {
 	status := "-"
 	if transaction.Status != nil && *transaction.Status != "" {
 		status = string(*transaction.Status)
@@ -245,7 +403,7 @@ func renderTransactionDetails(appCtx *app.Context, transaction *transactions.Tra
 		paymentType = string(*transaction.PaymentType)
 	}
 
-	display.DataList([]attribute.KeyValue{
+	return []attribute.KeyValue{
 		attribute.ID(util.StringOrDefault(transaction.ID, "-")),
 		attribute.Attribute("Status", attribute.Styled(status)),
 		attribute.Attribute("Code", attribute.Styled(util.StringOrDefault(transaction.TransactionCode, "-"))),
@@ -255,7 +413,7 @@ func renderTransactionDetails(appCtx *app.Context, transaction *transactions.Tra
 		attribute.Attribute("Card", attribute.Styled(transactionCardLabel(transaction.Card))),
 		attribute.Attribute("Description", attribute.Styled(util.StringOrDefault(transaction.ProductSummary, "-"))),
 		attribute.Attribute("Created At", attribute.Styled(util.TimeOrDash(appCtx, transaction.Timestamp))),
-	})
+	}
 }
 
 func transactionCardLabel(card *transactions.CardResponse) string {
@@ -0,0 +1,58 @@
+package util
+
+import (
+	"os"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/audit"
+	"github.com/sumup/sumup-cli/internal/display/message"
+)
+
+// RecordAudit appends the outcome of a state-changing command to the local
+// audit log. Failing to write the log only produces a warning.
+func RecordAudit(cmd *cli.Command, merchantCode, resourceID string, err error) {
+	RecordAuditAction(cmd, "", merchantCode, resourceID, err)
+}
+
+// RecordAuditAction records an operation started from within an interactive
+// command, such as a refund issued from the dashboard. The action is appended
+// to the command name.
+func RecordAuditAction(cmd *cli.Command, action, merchantCode, resourceID string, err error) {
+	command := cmd.FullName()
+	if action != "" {
+		command += " " + action
+	}
+	entry := audit.Entry{
+		Timestamp:    time.Now().UTC(),
+		User:         audit.CurrentUser(),
+		MerchantCode: merchantCode,
+		Command:      command,
+		Arguments:    audit.SanitizeArgs(commandArgs(cmd)),
+		ResourceID:   resourceID,
+		Outcome:      audit.OutcomeSuccess,
+	}
+	if appCtx, ctxErr := app.GetAppContext(cmd); ctxErr == nil {
+		entry.BaseURL = appCtx.BaseURL
+	}
+	if err != nil {
+		entry.Outcome = audit.OutcomeFailure
+		entry.Error = err.Error()
+	}
+
+	if writeErr := audit.Append(entry); writeErr != nil {
+		message.Warn("Failed to write audit log: %v", writeErr)
+	}
+}
+
+// commandArgs returns the arguments of the command line being run, which
+// inside the shell is the current line rather than the arguments of the
+// process.
+func commandArgs(cmd *cli.Command) []string {
+	if args, ok := cmd.Root().Metadata[app.ArgsKey].([]string); ok {
+		return args
+	}
+	return os.Args[1:]
+}
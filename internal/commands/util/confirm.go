@@ -0,0 +1,60 @@
+package util
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/display"
+	"github.com/sumup/sumup-cli/internal/display/attribute"
+	"github.com/sumup/sumup-cli/internal/display/message"
+)
+
+// Confirmation describes the resource a destructive command is about to act on.
+type Confirmation struct {
+	// Action is a short description of the operation, for example "delete reader".
+	Action string
+	// Name is the human-readable name of the resource. Against production it
+	// has to be typed to confirm the operation.
+	Name string
+	// Details are shown to the user before asking for confirmation.
+	Details []attribute.KeyValue
+}
+
+// Confirm asks the user to confirm a destructive operation by typing "yes", or
+// the resource name when running against production. It returns an error if
+// the operation was not confirmed. Non-interactive sessions have to pass --yes.
+func Confirm(appCtx *app.Context, target Confirmation) error {
+	if appCtx.AssumeYes {
+		return nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("refusing to %s without confirmation, pass --yes to proceed in non-interactive mode", target.Action)
+	}
+
+	// The whole prompt goes to stderr, so that it neither ends up in nor
+	// hides behind output redirected to a file.
+	message.Fwarn(os.Stderr, "You are about to %s:", target.Action)
+	fmt.Fprint(os.Stderr, display.DataListString(target.Details))
+
+	expected := "yes"
+	if appCtx.IsProduction() && target.Name != "" {
+		expected = target.Name
+	}
+	fmt.Fprintf(os.Stderr, "Type %q to confirm: ", expected)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("read confirmation: %w", err)
+	}
+	if strings.TrimSpace(answer) != expected {
+		return errors.New("confirmation did not match, aborting")
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/display/message"
+	"github.com/sumup/sumup-cli/internal/membership"
+)
+
+// defaultConcurrency is the number of merchants queried at the same time.
+const defaultConcurrency = 5
+
+// Merchant is a merchant a command runs for when fanning out.
+type Merchant struct {
+	Code string
+	Name string
+}
+
+// FanOutResult is the result of a command for one merchant.
+type FanOutResult[T any] struct {
+	MerchantCode string `json:"merchant_code"`
+	MerchantName string `json:"merchant_name,omitempty"`
+	Result       T      `json:"result,omitempty"`
+	Error        string `json:"error,omitempty"`
+	Err          error  `json:"-"`
+}
+
+// FanOutFlags returns the flags that run a merchant-scoped command for many
+// merchants.
+func FanOutFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "all-merchants",
+			Usage: "Run for every merchant you can access, including merchants of your organizations.",
+		},
+		&cli.StringFlag{
+			Name:  "org",
+			Usage: "Run for every merchant of the organization with this ID and its nested organizations.",
+		},
+		&cli.IntFlag{
+			Name:  "concurrency",
+			Usage: "Maximum number of merchants queried at the same time with --all-merchants or --org.",
+			Value: defaultConcurrency,
+		},
+	}
+}
+
+// FanOutMerchants resolves the merchants selected with --all-merchants or
+// --org, which take precedence over the merchant code. It returns false if
+// neither flag is set and the command runs for a single merchant.
+func FanOutMerchants(ctx context.Context, cmd *cli.Command) ([]Merchant, bool, error) {
+	allMerchants := cmd.Bool("all-merchants")
+	organizationID := cmd.String("org")
+	if !allMerchants && organizationID == "" {
+		return nil, false, nil
+	}
+	if allMerchants && organizationID != "" {
+		return nil, true, errors.New("--all-merchants and --org cannot be used together")
+	}
+
+	appCtx, err := app.GetAppContext(cmd)
+	if err != nil {
+		return nil, true, err
+	}
+	items, err := membership.Merchants(ctx, appCtx.Client, organizationID)
+	if err != nil {
+		return nil, true, fmt.Errorf("list memberships: %w", err)
+	}
+	if len(items) == 0 {
+		return nil, true, errors.New("no merchants found in your memberships")
+	}
+
+	merchants := make([]Merchant, 0, len(items))
+	for _, item := range items {
+		merchants = append(merchants, Merchant{Code: membership.MerchantCode(item), Name: item.Resource.Name})
+	}
+	return merchants, true, nil
+}
+
+// FanOut calls fn for every merchant, at most --concurrency at a time. The
+// results are in the order of the merchants, a failure for one merchant does
+// not stop the others.
+func FanOut[T any](ctx context.Context, cmd *cli.Command, merchants []Merchant, fn func(ctx context.Context, merchantCode string) (T, error)) []FanOutResult[T] {
+	limit := max(1, cmd.Int("concurrency"))
+	results := make([]FanOutResult[T], len(merchants))
+	semaphore := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i, merchant := range merchants {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			result, err := fn(ctx, merchant.Code)
+			results[i] = FanOutResult[T]{
+				MerchantCode: merchant.Code,
+				MerchantName: merchant.Name,
+				Result:       result,
+				Err:          err,
+			}
+			if err != nil {
+				results[i].Error = err.Error()
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// FanOutErrors reports the merchants that failed and returns an error if any
+// did, so the command exits with a non-zero status after printing the results
+// of the other merchants. JSON output carries the errors in the results.
+func FanOutErrors[T any](appCtx *app.Context, results []FanOutResult[T]) error {
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			if !appCtx.JSONOutput {
+				message.Error("%s: %v", result.MerchantCode, result.Err)
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d merchants failed", failed, len(results))
+	}
+	return nil
+}
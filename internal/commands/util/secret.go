@@ -0,0 +1,184 @@
+package util
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"golang.org/x/term"
+
+	"github.com/sumup/sumup-go/secret"
+
+	"github.com/sumup/sumup-cli/internal/display/message"
+)
+
+const (
+	// generatedSecretLength is the length of generated passwords.
+	generatedSecretLength = 20
+	// maxStdinSecret bounds how much is read for secrets passed on stdin.
+	maxStdinSecret = 64 * 1024
+)
+
+// Characters used for generated secrets. Look-alikes such as 0/O and 1/l are
+// left out so the password can be read out to the member.
+const (
+	secretLower   = "abcdefghijkmnopqrstuvwxyz"
+	secretUpper   = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	secretDigits  = "23456789"
+	secretSymbols = "!#%+-.:=?@_"
+)
+
+// SecretInput reads a secret such as a password or an API key without it
+// ending up in the shell history or the process list. The secret is taken,
+// in order of precedence, from:
+//
+//   - the plain --<name> flag, which is hidden and only kept for existing scripts,
+//   - --<name>-stdin, reading it from standard input,
+//   - --generate-<name>, if Generate is set,
+//   - a hidden prompt with confirmation when standard input is a terminal.
+type SecretInput struct {
+	// Name is the flag name, for example "password".
+	Name string
+	// Label is shown in prompts and messages, for example "Password".
+	Label string
+	// Generate offers --generate-<name> to create a random secret.
+	Generate bool
+}
+
+// Flags returns the flags used by Read.
+func (s SecretInput) Flags() []cli.Flag {
+	label := strings.ToLower(s.Label)
+	flags := []cli.Flag{
+		&cli.StringFlag{
+			Name:   s.Name,
+			Usage:  fmt.Sprintf("The %s in plain text. Insecure, prefer --%s-stdin or the prompt.", label, s.Name),
+			Hidden: true,
+		},
+		&cli.BoolFlag{
+			Name:  s.Name + "-stdin",
+			Usage: fmt.Sprintf("Read the %s from standard input.", label),
+		},
+	}
+	if s.Generate {
+		flags = append(flags, &cli.BoolFlag{
+			Name:  "generate-" + s.Name,
+			Usage: fmt.Sprintf("Generate a strong %s and show it once.", label),
+		})
+	}
+	return flags
+}
+
+// Read returns the secret and whether it was generated, in which case the
+// caller has to show it to the user.
+func (s SecretInput) Read(cmd *cli.Command) (secret.Secret, bool, error) {
+	sources := 0
+	for _, set := range []bool{cmd.IsSet(s.Name), cmd.Bool(s.Name + "-stdin"), s.Generate && cmd.Bool("generate-"+s.Name)} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return secret.Secret{}, false, fmt.Errorf("only one of --%[1]s, --%[1]s-stdin and --generate-%[1]s can be used", s.Name)
+	}
+
+	switch {
+	case cmd.IsSet(s.Name):
+		message.Warn("Passing the %s as a flag exposes it in your shell history, use --%s-stdin instead.", strings.ToLower(s.Label), s.Name)
+		return s.validate(cmd.String(s.Name))
+	case cmd.Bool(s.Name + "-stdin"):
+		data, err := io.ReadAll(io.LimitReader(os.Stdin, maxStdinSecret))
+		if err != nil {
+			return secret.Secret{}, false, fmt.Errorf("read %s from stdin: %w", strings.ToLower(s.Label), err)
+		}
+		return s.validate(strings.TrimRight(string(data), "\r\n"))
+	case s.Generate && cmd.Bool("generate-"+s.Name):
+		value, err := GenerateSecret()
+		if err != nil {
+			return secret.Secret{}, false, err
+		}
+		return secret.New(value), true, nil
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		hint := fmt.Sprintf("--%s-stdin", s.Name)
+		if s.Generate {
+			hint += fmt.Sprintf(" or --generate-%s", s.Name)
+		}
+		return secret.Secret{}, false, fmt.Errorf("%s is required, pass %s in non-interactive mode", strings.ToLower(s.Label), hint)
+	}
+	value, err := promptSecret(s.Label + ": ")
+	if err != nil {
+		return secret.Secret{}, false, err
+	}
+	if _, _, err := s.validate(value); err != nil {
+		return secret.Secret{}, false, err
+	}
+	confirmation, err := promptSecret("Confirm " + strings.ToLower(s.Label) + ": ")
+	if err != nil {
+		return secret.Secret{}, false, err
+	}
+	if confirmation != value {
+		return secret.Secret{}, false, fmt.Errorf("%s entries do not match", strings.ToLower(s.Label))
+	}
+	return secret.New(value), false, nil
+}
+
+func (s SecretInput) validate(value string) (secret.Secret, bool, error) {
+	if value == "" {
+		return secret.Secret{}, false, fmt.Errorf("%s must not be empty", strings.ToLower(s.Label))
+	}
+	return secret.New(value), false, nil
+}
+
+// promptSecret reads a line from the terminal without echoing it. The prompt
+// goes to stderr so it does not mix with JSON output.
+func promptSecret(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	value, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("read input: %w", err)
+	}
+	return string(value), nil
+}
+
+// GenerateSecret returns a random password with at least one lower case
+// letter, upper case letter, digit and symbol.
+func GenerateSecret() (string, error) {
+	classes := []string{secretLower, secretUpper, secretDigits, secretSymbols}
+	all := strings.Join(classes, "")
+
+	value := make([]byte, generatedSecretLength)
+	for i := range value {
+		charset := all
+		if i < len(classes) {
+			charset = classes[i]
+		}
+		c, err := randomChar(charset)
+		if err != nil {
+			return "", err
+		}
+		value[i] = c
+	}
+	// Shuffle so the guaranteed characters are not always in front.
+	for i := len(value) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", fmt.Errorf("generate secret: %w", err)
+		}
+		value[i], value[j.Int64()] = value[j.Int64()], value[i]
+	}
+	return string(value), nil
+}
+
+func randomChar(charset string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+	if err != nil {
+		return 0, fmt.Errorf("generate secret: %w", err)
+	}
+	return charset[n.Int64()], nil
+}
@@ -0,0 +1,50 @@
+package util
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Suggest returns the candidate closest to value, for "did you mean"
+// hints, or an empty string if none is close enough. Candidates are
+// compared case-insensitively.
+func Suggest(value string, candidates []string) string {
+	value = strings.ToLower(value)
+	best, bestDistance := "", -1
+	for _, candidate := range candidates {
+		lower := strings.ToLower(candidate)
+		distance := levenshtein(value, lower)
+		if strings.HasPrefix(lower, value) || strings.HasPrefix(value, lower) {
+			distance = min(distance, 1)
+		}
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	// Allow roughly one typo per four characters.
+	if bestDistance < 0 || bestDistance > max(2, utf8.RuneCountInString(value)/4) {
+		return ""
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
@@ -9,6 +9,9 @@ import (
 	"github.com/urfave/cli/v3"
 
 	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/display"
+	"github.com/sumup/sumup-cli/internal/display/message"
+	"github.com/sumup/sumup-cli/internal/webhook"
 )
 
 func RequireSingleArg(cmd *cli.Command, label string) (string, error) {
@@ -58,3 +61,38 @@ func BoolLabel(value *bool) string {
 	}
 	return "No"
 }
+
+// DryRun prints the request a mutating command would send instead of sending it.
+// The body is omitted when nil.
+func DryRun(appCtx *app.Context, method, path string, body any) error {
+	if appCtx.JSONOutput {
+		request := map[string]any{
+			"dry_run": true,
+			"method":  method,
+			"path":    path,
+		}
+		if body != nil {
+			request["body"] = body
+		}
+		return display.PrintJSON(request)
+	}
+
+	message.Notify("Dry run, no request was sent.")
+	fmt.Printf("%s %s\n", method, path)
+	if body == nil {
+		return nil
+	}
+	return display.PrintJSON(body)
+}
+
+// ReturnURL resolves the --return-url flag, or the URL of the running
+// `sumup listen` receiver when --listen is set.
+func ReturnURL(cmd *cli.Command) (string, error) {
+	if !cmd.Bool("listen") {
+		return cmd.String("return-url"), nil
+	}
+	if cmd.IsSet("return-url") {
+		return "", fmt.Errorf("--listen and --return-url cannot be used together")
+	}
+	return webhook.ListenerURL()
+}
@@ -0,0 +1,124 @@
+package completion
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/sumup/sumup-go/members"
+	"github.com/sumup/sumup-go/memberships"
+	"github.com/sumup/sumup-go/shared"
+
+	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/currency"
+)
+
+// Currencies suggests the currency codes supported by the CLI.
+func Currencies(ctx context.Context, appCtx *app.Context, cmd *cli.Command) ([]Suggestion, error) {
+	return Values(currency.Supported()...)(ctx, appCtx, cmd)
+}
+
+// MerchantCodes suggests the merchants the authenticated user is a member of.
+func MerchantCodes(ctx context.Context, appCtx *app.Context, _ *cli.Command) ([]Suggestion, error) {
+	return Cached(appCtx, "merchant-codes", func() ([]Suggestion, error) {
+		status := shared.MembershipStatusAccepted
+		resourceType := memberships.ResourceType("merchant")
+		response, err := appCtx.Client.Memberships.List(ctx, memberships.ListMembershipsParams{
+			Status:       &status,
+			ResourceType: &resourceType,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		suggestions := make([]Suggestion, 0, len(response.Items))
+		for _, membership := range response.Items {
+			suggestions = append(suggestions, Suggestion{
+				Value:       membership.Resource.ID,
+				Description: membership.Resource.Name,
+			})
+		}
+		return suggestions, nil
+	})
+}
+
+// Readers suggests the IDs of the readers paired with the merchant.
+func Readers(ctx context.Context, appCtx *app.Context, cmd *cli.Command) ([]Suggestion, error) {
+	merchantCode := MerchantCode(cmd)
+	if merchantCode == "" {
+		return nil, errors.New("merchant code is required")
+	}
+
+	return Cached(appCtx, "readers:"+merchantCode, func() ([]Suggestion, error) {
+		response, err := appCtx.Client.Readers.List(ctx, merchantCode)
+		if err != nil {
+			return nil, err
+		}
+
+		suggestions := make([]Suggestion, 0, len(response.Items))
+		for _, reader := range response.Items {
+			suggestions = append(suggestions, Suggestion{
+				Value:       string(reader.ID),
+				Description: strings.TrimSpace(string(reader.Name) + " " + string(reader.Device.Model)),
+			})
+		}
+		return suggestions, nil
+	})
+}
+
+// Members suggests the IDs of the merchant's members.
+func Members(ctx context.Context, appCtx *app.Context, cmd *cli.Command) ([]Suggestion, error) {
+	merchantCode := MerchantCode(cmd)
+	if merchantCode == "" {
+		return nil, errors.New("merchant code is required")
+	}
+
+	return Cached(appCtx, "members:"+merchantCode, func() ([]Suggestion, error) {
+		response, err := appCtx.Client.Members.List(ctx, merchantCode, members.ListMerchantMembersParams{})
+		if err != nil {
+			return nil, err
+		}
+
+		suggestions := make([]Suggestion, 0, len(response.Items))
+		for _, member := range response.Items {
+			email := ""
+			switch {
+			case member.User != nil:
+				email = member.User.Email
+			case member.Invite != nil:
+				email = member.Invite.Email
+			}
+			suggestions = append(suggestions, Suggestion{
+				Value:       member.ID,
+				Description: email,
+			})
+		}
+		return suggestions, nil
+	})
+}
+
+// Roles suggests the IDs of the merchant's roles.
+func Roles(ctx context.Context, appCtx *app.Context, cmd *cli.Command) ([]Suggestion, error) {
+	merchantCode := MerchantCode(cmd)
+	if merchantCode == "" {
+		return nil, errors.New("merchant code is required")
+	}
+
+	return Cached(appCtx, "roles:"+merchantCode, func() ([]Suggestion, error) {
+		response, err := appCtx.Client.Roles.List(ctx, merchantCode)
+		if err != nil {
+			return nil, err
+		}
+
+		suggestions := make([]Suggestion, 0, len(response.Items))
+		for _, role := range response.Items {
+			suggestions = append(suggestions, Suggestion{
+				Value:       role.ID,
+				Description: role.Name,
+			})
+		}
+		return suggestions, nil
+	})
+}
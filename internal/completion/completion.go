@@ -0,0 +1,216 @@
+package completion
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/cache"
+	"github.com/sumup/sumup-cli/internal/config"
+)
+
+// cacheTTL is how long suggestions fetched from the API are reused.
+const cacheTTL = 2 * time.Minute
+
+const completionFlag = "--generate-shell-completion"
+
+// Suggestion is a single completion candidate.
+type Suggestion struct {
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+}
+
+// Completer returns the suggestions for a flag value or positional argument.
+type Completer func(ctx context.Context, appCtx *app.Context, cmd *cli.Command) ([]Suggestion, error)
+
+// defaultFlags are completers for flags shared by many commands.
+var defaultFlags = map[string]Completer{
+	"merchant-code": MerchantCodes,
+	"currency":      Currencies,
+}
+
+// FlagCompleter returns the completer for values of a flag shared by many
+// commands, or nil if there is none.
+func FlagCompleter(name string) Completer {
+	return defaultFlags[name]
+}
+
+// Complete returns a shell completion function. Values of flags are completed
+// with the matching completer from flags, falling back to the completers of
+// flags shared by all commands, and the first positional argument with args.
+// Anything else is completed with the default flag and command suggestions.
+func Complete(args Completer, flags map[string]Completer) cli.ShellCompleteFunc {
+	return func(ctx context.Context, cmd *cli.Command) {
+		lastArg := previousArg()
+
+		if name, ok := pendingFlag(cmd, lastArg); ok {
+			completer, found := flags[name]
+			if !found {
+				completer, found = defaultFlags[name]
+			}
+			if found {
+				run(ctx, cmd, completer)
+			}
+			return
+		}
+
+		if strings.HasPrefix(lastArg, "-") && lookupFlag(cmd, lastArg) == nil {
+			printFlags(cmd, lastArg)
+			return
+		}
+
+		// Without suggestions for the argument, for example when there is
+		// no merchant code to list readers for, offer the flags instead.
+		if args != nil && cmd.Args().Len() == 0 && run(ctx, cmd, args) {
+			return
+		}
+
+		cli.DefaultCompleteWithFlags(ctx, cmd)
+	}
+}
+
+// Values returns a completer suggesting a fixed list of values.
+func Values(values ...string) Completer {
+	return func(context.Context, *app.Context, *cli.Command) ([]Suggestion, error) {
+		suggestions := make([]Suggestion, 0, len(values))
+		for _, value := range values {
+			suggestions = append(suggestions, Suggestion{Value: value})
+		}
+		return suggestions, nil
+	}
+}
+
+// Cached returns the suggestions stored under key if they are recent enough,
+// otherwise it calls fetch and stores its result.
+func Cached(appCtx *app.Context, key string, fetch func() ([]Suggestion, error)) ([]Suggestion, error) {
+	store := cache.New(appCtx, "completion")
+	var cached []Suggestion
+	if createdAt, ok := store.Load(key, &cached); ok && time.Since(createdAt) < cacheTTL {
+		return cached, nil
+	}
+
+	suggestions, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	store.Save(key, suggestions)
+	return suggestions, nil
+}
+
+// MerchantCode resolves the merchant code for completions. Environment
+// variables are not applied to flags during completion, so they are read
+// directly.
+func MerchantCode(cmd *cli.Command) string {
+	if cmd.IsSet("merchant-code") {
+		return cmd.String("merchant-code")
+	}
+	if value := os.Getenv("SUMUP_MERCHANT_CODE"); value != "" {
+		return value
+	}
+	merchantCode, err := config.GetCurrentMerchantCode()
+	if err != nil {
+		return ""
+	}
+	return merchantCode
+}
+
+// run prints the suggestions of the completer and reports whether there
+// were any.
+func run(ctx context.Context, cmd *cli.Command, completer Completer) bool {
+	appCtx, err := appContext(cmd)
+	if err != nil {
+		return false
+	}
+	suggestions, err := completer(ctx, appCtx, cmd)
+	if err != nil || len(suggestions) == 0 {
+		return false
+	}
+	printSuggestions(cmd.Root().Writer, suggestions)
+	return true
+}
+
+// appContext returns the application context. Completions run before the
+// root Before hook, so the context is usually built here from the flags.
+func appContext(cmd *cli.Command) (*app.Context, error) {
+	if appCtx, err := app.GetAppContext(cmd); err == nil {
+		return appCtx, nil
+	}
+	baseURL := cmd.String("base-url")
+	if value := os.Getenv("SUMUP_BASE_URL"); value != "" && !cmd.IsSet("base-url") {
+		baseURL = value
+	}
+	return app.NewContext(cmd.String("api-key"), baseURL, false, false, false, false)
+}
+
+// previousArg returns the last complete word before the completion flag.
+func previousArg() string {
+	args := os.Args
+	if len(args) > 0 && args[len(args)-1] == completionFlag {
+		args = args[:len(args)-1]
+	}
+	if len(args) < 2 {
+		return ""
+	}
+	return args[len(args)-1]
+}
+
+// pendingFlag reports whether arg is a flag that still awaits its value.
+func pendingFlag(cmd *cli.Command, arg string) (string, bool) {
+	if !strings.HasPrefix(arg, "-") || strings.Contains(arg, "=") {
+		return "", false
+	}
+	flag := lookupFlag(cmd, arg)
+	if flag == nil {
+		return "", false
+	}
+	docFlag, ok := flag.(cli.DocGenerationFlag)
+	if !ok || !docFlag.TakesValue() {
+		return "", false
+	}
+	return flag.Names()[0], true
+}
+
+// lookupFlag finds the flag named by arg on the command or its ancestors.
+func lookupFlag(cmd *cli.Command, arg string) cli.Flag {
+	name := strings.TrimLeft(arg, "-")
+	for _, c := range cmd.Lineage() {
+		for _, flag := range c.Flags {
+			if slices.Contains(flag.Names(), name) {
+				return flag
+			}
+		}
+	}
+	return nil
+}
+
+// printFlags suggests the flags of the command starting with the partially
+// typed prefix.
+func printFlags(cmd *cli.Command, prefix string) {
+	name := strings.TrimLeft(prefix, "-")
+	for _, c := range cmd.Lineage() {
+		for _, flag := range c.Flags {
+			flagName := flag.Names()[0]
+			if strings.HasPrefix(flagName, name) {
+				fmt.Fprintf(cmd.Root().Writer, "--%s\n", flagName)
+			}
+		}
+	}
+}
+
+func printSuggestions(w io.Writer, suggestions []Suggestion) {
+	zsh := strings.HasSuffix(os.Getenv("SHELL"), "zsh")
+	for _, suggestion := range suggestions {
+		if zsh && suggestion.Description != "" {
+			fmt.Fprintf(w, "%s:%s\n", strings.ReplaceAll(suggestion.Value, ":", "\\:"), suggestion.Description)
+			continue
+		}
+		fmt.Fprintln(w, suggestion.Value)
+	}
+}
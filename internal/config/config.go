@@ -6,11 +6,44 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strings"
+	"time"
 )
 
+// recentLimit is the number of recently used merchants kept in the config.
+const recentLimit = 10
+
 // Config holds the CLI configuration.
 type Config struct {
 	CurrentMerchantCode string `json:"current_merchant_code,omitempty"`
+	// PreviousMerchantCode is the merchant context before the last change,
+	// restored by 'context use -'.
+	PreviousMerchantCode string `json:"previous_merchant_code,omitempty"`
+	// RecentMerchants lists the merchants used as context, most recent first.
+	RecentMerchants []RecentMerchant `json:"recent_merchants,omitempty"`
+	// FavoriteMerchants are starred in the context picker and listed first.
+	FavoriteMerchants []FavoriteMerchant `json:"favorite_merchants,omitempty"`
+	// PluginDir overrides the directory searched for sumup-<name> plugins.
+	PluginDir string `json:"plugin_dir,omitempty"`
+	// Aliases maps alias names to the command line they expand to.
+	Aliases map[string]string `json:"aliases,omitempty"`
+	// Defaults maps a command path such as "readers checkout" to flag values
+	// that apply unless the flag is set explicitly.
+	Defaults map[string]map[string][]string `json:"defaults,omitempty"`
+}
+
+// RecentMerchant is a merchant that was used as context.
+type RecentMerchant struct {
+	MerchantCode string    `json:"merchant_code"`
+	Name         string    `json:"name,omitempty"`
+	UsedAt       time.Time `json:"used_at"`
+}
+
+// FavoriteMerchant is a merchant starred in the context picker.
+type FavoriteMerchant struct {
+	MerchantCode string `json:"merchant_code"`
+	Name         string `json:"name,omitempty"`
 }
 
 // configDir returns the platform-specific configuration directory.
@@ -43,6 +76,17 @@ func configDir() (string, error) {
 	return filepath.Join(baseDir, "sumup"), nil
 }
 
+// Dir returns the directory holding the configuration file and other local
+// CLI state.
+func Dir() (string, error) {
+	return configDir()
+}
+
+// Path returns the path to the configuration file.
+func Path() (string, error) {
+	return configPath()
+}
+
 // configPath returns the path to the configuration file.
 func configPath() (string, error) {
 	dir, err := configDir()
@@ -114,6 +158,82 @@ func SetCurrentMerchantCode(merchantCode string) error {
 	if err != nil {
 		return err
 	}
-	cfg.CurrentMerchantCode = merchantCode
+	cfg.setCurrentMerchantCode(merchantCode)
 	return cfg.Save()
 }
+
+// UseMerchant sets the current merchant code in config and records it as
+// recently used.
+func UseMerchant(merchantCode, name string) error {
+	cfg, err := Load()
+	if err != nil {
+		return err
+	}
+	cfg.setCurrentMerchantCode(merchantCode)
+	cfg.RecentMerchants = slices.DeleteFunc(cfg.RecentMerchants, func(recent RecentMerchant) bool {
+		return strings.EqualFold(recent.MerchantCode, merchantCode)
+	})
+	cfg.RecentMerchants = slices.Insert(cfg.RecentMerchants, 0, RecentMerchant{
+		MerchantCode: merchantCode,
+		Name:         name,
+		UsedAt:       time.Now().UTC(),
+	})
+	if len(cfg.RecentMerchants) > recentLimit {
+		cfg.RecentMerchants = cfg.RecentMerchants[:recentLimit]
+	}
+	return cfg.Save()
+}
+
+// ToggleFavoriteMerchant stars the merchant, or removes the star if it is
+// already a favourite. It reports whether the merchant is a favourite now.
+func ToggleFavoriteMerchant(merchantCode, name string) (bool, error) {
+	cfg, err := Load()
+	if err != nil {
+		return false, err
+	}
+	isFavorite := func(favorite FavoriteMerchant) bool {
+		return strings.EqualFold(favorite.MerchantCode, merchantCode)
+	}
+	starred := !slices.ContainsFunc(cfg.FavoriteMerchants, isFavorite)
+	if starred {
+		cfg.FavoriteMerchants = append(cfg.FavoriteMerchants, FavoriteMerchant{MerchantCode: merchantCode, Name: name})
+	} else {
+		cfg.FavoriteMerchants = slices.DeleteFunc(cfg.FavoriteMerchants, isFavorite)
+	}
+	return starred, cfg.Save()
+}
+
+// RecentMerchantName returns the name recorded for a recently used merchant.
+func (c *Config) RecentMerchantName(merchantCode string) string {
+	for _, recent := range c.RecentMerchants {
+		if strings.EqualFold(recent.MerchantCode, merchantCode) {
+			return recent.Name
+		}
+	}
+	return ""
+}
+
+// setCurrentMerchantCode remembers the replaced merchant code as previous.
+func (c *Config) setCurrentMerchantCode(merchantCode string) {
+	if c.CurrentMerchantCode != "" && c.CurrentMerchantCode != merchantCode {
+		c.PreviousMerchantCode = c.CurrentMerchantCode
+	}
+	c.CurrentMerchantCode = merchantCode
+}
+
+// PluginDir returns the directory searched for plugin executables. It
+// defaults to the plugins directory next to the configuration file.
+func PluginDir() (string, error) {
+	cfg, err := Load()
+	if err != nil {
+		return "", err
+	}
+	if cfg.PluginDir != "" {
+		return cfg.PluginDir, nil
+	}
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "plugins"), nil
+}
@@ -121,6 +121,16 @@ func Supported() []string {
 	}
 }
 
+// Decimals returns the number of minor unit digits of the currency,
+// defaulting to two for currencies the CLI does not know.
+func Decimals(currency shared.Currency) int32 {
+	info, ok := infoByCurrency[currency]
+	if !ok {
+		return 2
+	}
+	return info.decimals
+}
+
 // Code returns the ISO code string representation of the currency.
 func Code(currency shared.Currency) string {
 	return string(currency)
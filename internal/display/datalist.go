@@ -2,20 +2,25 @@ package display
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/sumup/sumup-cli/internal/display/attribute"
 )
 
 // DataList renders key/value pairs as "Key: Value" rows where keys are bold.
 func DataList(pairs []attribute.KeyValue) {
-	if len(pairs) == 0 {
-		return
-	}
+	fmt.Print(DataListString(pairs))
+}
 
+// DataListString formats key/value pairs the same way as DataList, for
+// embedding them in interactive views.
+func DataListString(pairs []attribute.KeyValue) string {
+	var b strings.Builder
 	for _, pair := range pairs {
 		if pair.Key.V == "" {
 			continue
 		}
-		fmt.Printf("%s: %s\n", pair.Key.String(), pair.Value.String())
+		fmt.Fprintf(&b, "%s: %s\n", pair.Key.String(), pair.Value.String())
 	}
+	return b.String()
 }
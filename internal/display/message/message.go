@@ -1,6 +1,10 @@
 package message
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+	"os"
+)
 
 const (
 	resetColor  = "\033[0m"
@@ -27,6 +31,12 @@ func Warn(format string, args ...any) {
 	printColored(yellowColor, warnSymbol, format, args...)
 }
 
+// Fwarn prints a warning like Warn to w, for output that must not mix with
+// the results written to stdout.
+func Fwarn(w io.Writer, format string, args ...any) {
+	fprintColored(w, yellowColor, warnSymbol, format, args...)
+}
+
 // Notify prints a blue informational message prefixed with an info sign.
 func Notify(format string, args ...any) {
 	printColored(blueColor, notifySymbol, format, args...)
@@ -38,9 +48,13 @@ func Error(format string, args ...any) {
 }
 
 func printColored(colorCode, symbol, format string, args ...any) {
+	fprintColored(os.Stdout, colorCode, symbol, format, args...)
+}
+
+func fprintColored(w io.Writer, colorCode, symbol, format string, args ...any) {
 	message := format
 	if len(args) > 0 {
 		message = fmt.Sprintf(format, args...)
 	}
-	fmt.Printf("%s%s %s%s\n", colorCode, symbol, message, resetColor)
+	fmt.Fprintf(w, "%s%s %s%s\n", colorCode, symbol, message, resetColor)
 }
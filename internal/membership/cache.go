@@ -0,0 +1,57 @@
+package membership
+
+import (
+	"time"
+
+	"github.com/sumup/sumup-go/memberships"
+
+	"github.com/sumup/sumup-cli/internal/app"
+	"github.com/sumup/sumup-cli/internal/cache"
+)
+
+// CacheTTL is how long cached memberships are used without refreshing them.
+const CacheTTL = 10 * time.Minute
+
+// Cache stores the memberships of the user on disk, per API host and key, so
+// the context picker opens without waiting for the API.
+type Cache struct {
+	store *cache.Cache
+}
+
+// CacheEntry is a cached list of memberships.
+type CacheEntry struct {
+	CreatedAt time.Time                `json:"-"`
+	Items     []memberships.Membership `json:"items"`
+	// Total is the number of memberships the API reported, which may exceed
+	// the number of items loaded so far.
+	Total int `json:"total"`
+}
+
+// Stale reports whether the entry is older than CacheTTL.
+func (e CacheEntry) Stale() bool {
+	return time.Since(e.CreatedAt) >= CacheTTL
+}
+
+// NewCache returns the membership cache for the API host and key of appCtx.
+func NewCache(appCtx *app.Context) *Cache {
+	return &Cache{store: cache.New(appCtx, "memberships")}
+}
+
+// Load returns the cached memberships of the resources in the organization,
+// or the top level memberships for an empty organizationID. Stale entries
+// are returned as well, callers refresh them.
+func (c *Cache) Load(organizationID string) (*CacheEntry, bool) {
+	var entry CacheEntry
+	createdAt, ok := c.store.Load(organizationID, &entry)
+	if !ok {
+		return nil, false
+	}
+	entry.CreatedAt = createdAt
+	return &entry, true
+}
+
+// Save stores the memberships. Failures are ignored, the cache only speeds up
+// the next start.
+func (c *Cache) Save(organizationID string, items []memberships.Membership, total int) {
+	c.store.Save(organizationID, CacheEntry{Items: items, Total: total})
+}
@@ -0,0 +1,211 @@
+// Package membership resolves merchants and organizations from the
+// memberships of the authenticated user.
+package membership
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/sumup/sumup-go"
+	"github.com/sumup/sumup-go/memberships"
+	"github.com/sumup/sumup-go/shared"
+)
+
+const (
+	// pageSize is the number of memberships requested per page.
+	pageSize = 100
+	// maxDepth bounds how deep nested organizations are searched.
+	maxDepth = 5
+
+	TypeMerchant     memberships.ResourceType = "merchant"
+	TypeOrganization memberships.ResourceType = "organization"
+)
+
+// ErrNotFound is returned when a merchant is not reachable through any
+// membership of the user.
+var ErrNotFound = errors.New("merchant not found in your memberships")
+
+// Path is a membership together with the organizations it was found in,
+// outermost first.
+type Path struct {
+	Membership    memberships.Membership
+	Organizations []memberships.MembershipResource
+}
+
+// OrganizationPath returns the names of the organizations joined with " / ".
+func (p Path) OrganizationPath() string {
+	names := make([]string, 0, len(p.Organizations))
+	for _, org := range p.Organizations {
+		names = append(names, org.Name)
+	}
+	return strings.Join(names, " / ")
+}
+
+// List returns all accepted memberships matching the params, following
+// pagination.
+func List(ctx context.Context, client *sumup.Client, params memberships.ListMembershipsParams) ([]memberships.Membership, error) {
+	status := shared.MembershipStatusAccepted
+	params.Status = &status
+	limit := pageSize
+	params.Limit = &limit
+
+	var items []memberships.Membership
+	for offset := 0; ; offset += pageSize {
+		params.Offset = &offset
+		response, err := client.Memberships.List(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, response.Items...)
+		if len(response.Items) < pageSize || (response.TotalCount > 0 && len(items) >= response.TotalCount) {
+			return items, nil
+		}
+	}
+}
+
+// Children returns the accepted memberships in resources belonging to the
+// organization.
+func Children(ctx context.Context, client *sumup.Client, organizationID string) ([]memberships.Membership, error) {
+	parentType := TypeOrganization
+	return List(ctx, client, memberships.ListMembershipsParams{
+		ResourceParentId:   &organizationID,
+		ResourceParentType: &parentType,
+	})
+}
+
+// MerchantCode returns the merchant code of a merchant membership.
+func MerchantCode(m memberships.Membership) string {
+	if code, ok := m.Resource.Attributes["merchant_code"].(string); ok && code != "" {
+		return code
+	}
+	return m.Resource.ID
+}
+
+// FindMerchant searches the memberships of the user for the merchant,
+// descending into organizations breadth first.
+func FindMerchant(ctx context.Context, client *sumup.Client, merchantCode string) (*Path, error) {
+	items, err := List(ctx, client, memberships.ListMembershipsParams{})
+	if err != nil {
+		return nil, err
+	}
+
+	type level struct {
+		items []memberships.Membership
+		orgs  []memberships.MembershipResource
+	}
+	queue := []level{{items: items}}
+	visited := map[string]bool{}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, m := range current.items {
+			if m.Resource.Type == TypeMerchant && strings.EqualFold(MerchantCode(m), merchantCode) {
+				return &Path{Membership: m, Organizations: current.orgs}, nil
+			}
+		}
+		if len(current.orgs) >= maxDepth {
+			continue
+		}
+		for _, m := range current.items {
+			if m.Resource.Type != TypeOrganization || visited[m.Resource.ID] {
+				continue
+			}
+			visited[m.Resource.ID] = true
+			children, err := Children(ctx, client, m.Resource.ID)
+			if err != nil {
+				return nil, err
+			}
+			queue = append(queue, level{
+				items: children,
+				orgs:  append(append([]memberships.MembershipResource{}, current.orgs...), m.Resource),
+			})
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// Merchants returns the merchant memberships in the organization and its
+// nested organizations, or all merchants the user can access for an empty
+// organizationID. Merchants reachable in several ways are returned once.
+func Merchants(ctx context.Context, client *sumup.Client, organizationID string) ([]memberships.Membership, error) {
+	var (
+		items []memberships.Membership
+		err   error
+	)
+	if organizationID == "" {
+		items, err = List(ctx, client, memberships.ListMembershipsParams{})
+	} else {
+		items, err = Children(ctx, client, organizationID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var merchants []memberships.Membership
+	seen := map[string]bool{}
+	visited := map[string]bool{organizationID: true}
+	for depth := 0; len(items) > 0 && depth <= maxDepth; depth++ {
+		var next []memberships.Membership
+		for _, m := range items {
+			switch m.Resource.Type {
+			case TypeMerchant:
+				code := strings.ToUpper(MerchantCode(m))
+				if !seen[code] {
+					seen[code] = true
+					merchants = append(merchants, m)
+				}
+			case TypeOrganization:
+				if visited[m.Resource.ID] {
+					continue
+				}
+				visited[m.Resource.ID] = true
+				children, err := Children(ctx, client, m.Resource.ID)
+				if err != nil {
+					return nil, err
+				}
+				next = append(next, children...)
+			}
+		}
+		items = next
+	}
+	return merchants, nil
+}
+
+// Node is a membership with the memberships in the resources below it, for
+// organizations.
+type Node struct {
+	Membership memberships.Membership `json:"membership"`
+	Children   []Node                 `json:"children,omitempty"`
+}
+
+// Tree returns the accepted memberships of the user with the resources of
+// their organizations nested below them. Organizations seen before are not
+// descended into again.
+func Tree(ctx context.Context, client *sumup.Client) ([]Node, error) {
+	items, err := List(ctx, client, memberships.ListMembershipsParams{})
+	if err != nil {
+		return nil, err
+	}
+	return tree(ctx, client, items, map[string]bool{}, 0)
+}
+
+func tree(ctx context.Context, client *sumup.Client, items []memberships.Membership, visited map[string]bool, depth int) ([]Node, error) {
+	nodes := make([]Node, 0, len(items))
+	for _, m := range items {
+		node := Node{Membership: m}
+		if m.Resource.Type == TypeOrganization && !visited[m.Resource.ID] && depth < maxDepth {
+			visited[m.Resource.ID] = true
+			children, err := Children(ctx, client, m.Resource.ID)
+			if err != nil {
+				return nil, err
+			}
+			node.Children, err = tree(ctx, client, children, visited, depth+1)
+			if err != nil {
+				return nil, err
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
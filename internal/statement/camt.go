@@ -0,0 +1,140 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// camtDocument covers the parts of a CAMT.053 bank to customer statement that
+// are needed to match bookings. Element names are matched regardless of the
+// namespace, so all versions of the message are read.
+type camtDocument struct {
+	Statements []struct {
+		Account struct {
+			Currency string `xml:"Ccy"`
+		} `xml:"Acct"`
+		Entries []camtEntry `xml:"Ntry"`
+	} `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtEntry struct {
+	Amount struct {
+		Value    string `xml:",chardata"`
+		Currency string `xml:"Ccy,attr"`
+	} `xml:"Amt"`
+	CreditDebit string   `xml:"CdtDbtInd"`
+	BookingDate camtDate `xml:"BookgDt"`
+	ValueDate   camtDate `xml:"ValDt"`
+	Reference   string   `xml:"AcctSvcrRef"`
+	Info        string   `xml:"AddtlNtryInf"`
+	Details     []struct {
+		EndToEndID   string   `xml:"Refs>EndToEndId"`
+		Unstructured []string `xml:"RmtInf>Ustrd"`
+		Structured   []string `xml:"RmtInf>Strd>CdtrRefInf>Ref"`
+		Debtor       string   `xml:"RltdPties>Dbtr>Nm"`
+		DebtorParty  string   `xml:"RltdPties>Dbtr>Pty>Nm"`
+		Info         string   `xml:"AddtlTxInf"`
+	} `xml:"NtryDtls>TxDtls"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+func (d camtDate) parse() (time.Time, bool) {
+	if d.Date != "" {
+		parsed, err := time.Parse(time.DateOnly, strings.TrimSpace(d.Date))
+		return parsed, err == nil
+	}
+	if d.DateTime != "" {
+		value := strings.TrimSpace(d.DateTime)
+		for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05"} {
+			if parsed, err := time.Parse(layout, value); err == nil {
+				return time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, time.UTC), true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func parseCAMT053(data []byte) ([]Line, error) {
+	var document camtDocument
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	if len(document.Statements) == 0 {
+		return nil, errors.New("no BkToCstmrStmt/Stmt element, is this a CAMT.053 file?")
+	}
+
+	var lines []Line
+	for _, stmt := range document.Statements {
+		for _, entry := range stmt.Entries {
+			position := len(lines) + 1
+			amount, err := decimal.NewFromString(strings.TrimSpace(entry.Amount.Value))
+			if err != nil {
+				return nil, fmt.Errorf("entry %d: invalid amount %q", position, entry.Amount.Value)
+			}
+			// CdtDbtInd is the direction of the entry itself, also for
+			// reversals: RvslInd only marks that it reverses an earlier one.
+			if strings.EqualFold(entry.CreditDebit, "DBIT") {
+				amount = amount.Neg()
+			}
+
+			date, ok := entry.BookingDate.parse()
+			if !ok {
+				date, ok = entry.ValueDate.parse()
+			}
+			if !ok {
+				return nil, fmt.Errorf("entry %d: missing booking date", position)
+			}
+
+			currency := entry.Amount.Currency
+			if currency == "" {
+				currency = stmt.Account.Currency
+			}
+
+			var references []string
+			var counterparty string
+			for _, details := range entry.Details {
+				references = append(references, details.Unstructured...)
+				references = append(references, details.Structured...)
+				if details.EndToEndID != "" && details.EndToEndID != "NOTPROVIDED" {
+					references = append(references, details.EndToEndID)
+				}
+				if details.Info != "" {
+					references = append(references, details.Info)
+				}
+				if counterparty == "" {
+					counterparty = details.Debtor
+				}
+				if counterparty == "" {
+					counterparty = details.DebtorParty
+				}
+			}
+			if entry.Info != "" {
+				references = append(references, entry.Info)
+			}
+			if len(references) == 0 && entry.Reference != "" {
+				references = append(references, entry.Reference)
+			}
+
+			lines = append(lines, Line{
+				Position:     position,
+				Date:         date,
+				Amount:       amount,
+				Currency:     currency,
+				Reference:    CleanReference(strings.Join(references, " ")),
+				Counterparty: CleanReference(counterparty),
+			})
+		}
+	}
+	return lines, nil
+}
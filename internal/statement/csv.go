@@ -0,0 +1,210 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// CSV header names, compared in lower case. German names are included since
+// German banks export CSV statements with them.
+var (
+	csvDateColumns         = []string{"booking date", "date", "buchungstag", "buchungsdatum", "value date", "valuta", "wertstellung"}
+	csvAmountColumns       = []string{"amount", "betrag", "umsatz"}
+	csvCreditColumns       = []string{"credit", "haben", "paid in"}
+	csvDebitColumns        = []string{"debit", "soll", "paid out"}
+	csvCurrencyColumns     = []string{"currency", "währung", "waehrung"}
+	csvReferenceColumns    = []string{"reference", "description", "purpose", "remittance information", "verwendungszweck", "buchungstext", "text"}
+	csvCounterpartyColumns = []string{"counterparty", "name", "payer", "auftraggeber", "beguenstigter/zahlungspflichtiger", "begünstigter/zahlungspflichtiger"}
+)
+
+// csvDateLayouts are tried in order. Slashed dates are read day first, as
+// in the European bank exports this is meant for.
+var csvDateLayouts = []string{"2006-01-02", "02.01.2006", "02.01.06", "02/01/2006", "2006/01/02"}
+
+type csvColumns struct {
+	date, amount, credit, debit, currency, counterparty int
+	references                                          []int
+}
+
+// parseCSV reads a statement with a header row. The delimiter is a comma or
+// a semicolon, amounts may use a decimal comma, and money in and out may be
+// in one signed amount column or in separate credit and debit columns.
+func parseCSV(data []byte) ([]Line, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = csvDelimiter(data)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("the file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := csvHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []Line
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		// The reader skips blank lines, so ask it for the line number.
+		number, _ := reader.FieldPos(0)
+		if len(strings.Join(record, "")) == 0 {
+			continue
+		}
+		field := func(index int) string {
+			if index < 0 || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+
+		date, err := parseCSVDate(field(columns.date))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", number, err)
+		}
+
+		var amount decimal.Decimal
+		if columns.amount >= 0 {
+			amount, err = parseCSVAmount(field(columns.amount))
+		} else {
+			var credit, debit decimal.Decimal
+			if credit, err = parseCSVAmount(field(columns.credit)); err == nil {
+				debit, err = parseCSVAmount(field(columns.debit))
+			}
+			amount = credit.Abs().Sub(debit.Abs())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", number, err)
+		}
+
+		var references []string
+		for _, index := range columns.references {
+			if value := field(index); value != "" {
+				references = append(references, value)
+			}
+		}
+
+		lines = append(lines, Line{
+			Position:     number,
+			Date:         date,
+			Amount:       amount,
+			Currency:     strings.ToUpper(field(columns.currency)),
+			Reference:    CleanReference(strings.Join(references, " ")),
+			Counterparty: CleanReference(field(columns.counterparty)),
+		})
+	}
+	return lines, nil
+}
+
+func csvDelimiter(data []byte) rune {
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
+
+func csvHeader(header []string) (csvColumns, error) {
+	find := func(names []string) int {
+		for _, name := range names {
+			for i, column := range header {
+				if strings.EqualFold(strings.TrimSpace(column), name) {
+					return i
+				}
+			}
+		}
+		return -1
+	}
+
+	columns := csvColumns{
+		date:         find(csvDateColumns),
+		amount:       find(csvAmountColumns),
+		credit:       find(csvCreditColumns),
+		debit:        find(csvDebitColumns),
+		currency:     find(csvCurrencyColumns),
+		counterparty: find(csvCounterpartyColumns),
+	}
+	for _, name := range csvReferenceColumns {
+		if index := find([]string{name}); index >= 0 {
+			columns.references = append(columns.references, index)
+		}
+	}
+
+	if columns.date < 0 {
+		return columns, fmt.Errorf("no date column, expected one of: %s", strings.Join(csvDateColumns, ", "))
+	}
+	if columns.amount < 0 && (columns.credit < 0 || columns.debit < 0) {
+		return columns, fmt.Errorf("no amount column, expected one of %s, or both a credit and a debit column", strings.Join(csvAmountColumns, ", "))
+	}
+	return columns, nil
+}
+
+func parseCSVDate(value string) (time.Time, error) {
+	for _, layout := range csvDateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD or DD.MM.YYYY", value)
+}
+
+// parseCSVAmount reads amounts such as "1234.56", "-1.234,56" or "1,234.56".
+// An empty value is zero, for the unused one of the credit and debit columns.
+//
+// With both separators in the amount, the last one is the decimal separator.
+// A separator that appears several times, or once followed by exactly three
+// digits as in "1.234" or "1,234", separates thousands, since bank amounts do
+// not have three decimals. Any other single separator is the decimal one.
+func parseCSVAmount(value string) (decimal.Decimal, error) {
+	cleaned := strings.NewReplacer(" ", "", "\u00a0", "", "'", "").Replace(value)
+	if cleaned == "" {
+		return decimal.Zero, nil
+	}
+	comma, dot := strings.LastIndex(cleaned, ","), strings.LastIndex(cleaned, ".")
+	switch {
+	case comma >= 0 && dot >= 0:
+		decimalSeparator, thousands := ".", ","
+		if comma > dot {
+			decimalSeparator, thousands = ",", "."
+		}
+		cleaned = strings.ReplaceAll(cleaned, thousands, "")
+		cleaned = strings.Replace(cleaned, decimalSeparator, ".", 1)
+	case comma >= 0:
+		cleaned = normalizeSeparator(cleaned, ",")
+	case dot >= 0:
+		cleaned = normalizeSeparator(cleaned, ".")
+	}
+	amount, err := decimal.NewFromString(cleaned)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid amount %q", value)
+	}
+	return amount, nil
+}
+
+// normalizeSeparator rewrites an amount with a single kind of separator to
+// use a decimal point, following the rules of parseCSVAmount.
+func normalizeSeparator(value, separator string) string {
+	index := strings.LastIndex(value, separator)
+	if strings.Count(value, separator) > 1 || len(value)-index-1 == 3 {
+		return strings.ReplaceAll(value, separator, "")
+	}
+	return strings.Replace(value, separator, ".", 1)
+}
@@ -0,0 +1,136 @@
+package statement
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// mt940Tag matches the start of a field such as ":61:" or ":60F:".
+	mt940Tag = regexp.MustCompile(`^:(\d{2}[A-Z]?):`)
+	// mt940Line matches the statement line field: value date, optional entry
+	// date, debit/credit mark, optional funds code, amount and the rest.
+	mt940Line = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?(\d+,\d*)(.*)$`)
+	// mt940Balance matches the opening balance field to learn the currency.
+	mt940Balance = regexp.MustCompile(`^[CD]\d{6}([A-Z]{3})`)
+	// mt940Subfield matches the ?NN separators of structured :86: fields.
+	mt940Subfield = regexp.MustCompile(`\?\d{2}`)
+	// mt940BusinessCode matches the transaction code that structured :86:
+	// fields start with.
+	mt940BusinessCode = regexp.MustCompile(`^\d{3}\?`)
+)
+
+type mt940Field struct {
+	tag   string
+	value string
+	line  int
+}
+
+func parseMT940(data []byte) ([]Line, error) {
+	var fields []mt940Field
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if match := mt940Tag.FindStringSubmatch(text); match != nil {
+			fields = append(fields, mt940Field{tag: match[1], value: text[len(match[0]):], line: number})
+			continue
+		}
+		// Continuation lines belong to the previous field, except for the
+		// block markers some banks wrap messages in.
+		if len(fields) > 0 && text != "-" && !strings.HasPrefix(text, "{") {
+			fields[len(fields)-1].value += "\n" + text
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var lines []Line
+	currency := ""
+	for i, field := range fields {
+		switch field.tag {
+		case "60F", "60M":
+			if match := mt940Balance.FindStringSubmatch(field.value); match != nil {
+				currency = match[1]
+			}
+		case "61":
+			line, err := parseMT940Line(field, currency)
+			if err != nil {
+				return nil, err
+			}
+			if i+1 < len(fields) && fields[i+1].tag == "86" {
+				info := strings.ReplaceAll(fields[i+1].value, "\n", "")
+				if mt940BusinessCode.MatchString(info) {
+					info = info[3:]
+				}
+				info = mt940Subfield.ReplaceAllString(info, " ")
+				line.Reference = CleanReference(line.Reference + " " + info)
+			}
+			line.Position = len(lines) + 1
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+func parseMT940Line(field mt940Field, currency string) (Line, error) {
+	first, rest, _ := strings.Cut(field.value, "\n")
+	match := mt940Line.FindStringSubmatch(first)
+	if match == nil {
+		return Line{}, fmt.Errorf("line %d: invalid :61: field %q", field.line, first)
+	}
+
+	date, err := time.Parse("060102", match[1])
+	if err != nil {
+		return Line{}, fmt.Errorf("line %d: invalid value date %q", field.line, match[1])
+	}
+	// The entry date only has month and day, it is the booking date and
+	// falls in the year of the value date, or the one before or after.
+	if match[2] != "" {
+		if entry, err := time.Parse("0102", match[2]); err == nil {
+			booked := time.Date(date.Year(), entry.Month(), entry.Day(), 0, 0, 0, 0, time.UTC)
+			switch {
+			case booked.Sub(date) > 180*24*time.Hour:
+				booked = booked.AddDate(-1, 0, 0)
+			case date.Sub(booked) > 180*24*time.Hour:
+				booked = booked.AddDate(1, 0, 0)
+			}
+			date = booked
+		}
+	}
+
+	amount, err := decimal.NewFromString(strings.Replace(match[5], ",", ".", 1))
+	if err != nil {
+		return Line{}, fmt.Errorf("line %d: invalid amount %q", field.line, match[5])
+	}
+	// RC, the reversal of a credit, is a debit and RD a credit.
+	if match[3] == "D" || match[3] == "RC" {
+		amount = amount.Neg()
+	}
+
+	// What follows the amount is the transaction type, the customer
+	// reference and, after "//", the bank reference.
+	reference := match[6]
+	if len(reference) >= 4 {
+		reference = reference[4:]
+	}
+	customer, _, _ := strings.Cut(reference, "//")
+	if customer == "NONREF" {
+		customer = ""
+	}
+
+	return Line{
+		Date:      date,
+		Amount:    amount,
+		Currency:  currency,
+		Reference: CleanReference(customer + " " + rest),
+	}, nil
+}
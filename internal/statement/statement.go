@@ -0,0 +1,90 @@
+// Package statement reads bank account statements in the CAMT.053, MT940 and
+// CSV formats into a common list of booked lines.
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Formats understood by Read.
+const (
+	FormatAuto    = "auto"
+	FormatCAMT053 = "camt053"
+	FormatMT940   = "mt940"
+	FormatCSV     = "csv"
+)
+
+// Line is a booking on the statement. Credits have a positive amount, debits
+// a negative one.
+type Line struct {
+	// Position is the number of the line within the file, counted from one,
+	// or the line number in the file for CSV statements.
+	Position     int             `json:"position"`
+	Date         time.Time       `json:"date"`
+	Amount       decimal.Decimal `json:"amount"`
+	Currency     string          `json:"currency,omitempty"`
+	Reference    string          `json:"reference,omitempty"`
+	Counterparty string          `json:"counterparty,omitempty"`
+}
+
+// Formats returns the formats accepted by Read.
+func Formats() []string {
+	return []string{FormatAuto, FormatCAMT053, FormatMT940, FormatCSV}
+}
+
+// Read parses the statement at path. With FormatAuto the format is detected
+// from the content of the file.
+func Read(path, format string) ([]Line, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read statement: %w", err)
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	if format == "" || format == FormatAuto {
+		format = Detect(path, data)
+	}
+
+	var lines []Line
+	switch format {
+	case FormatCAMT053:
+		lines, err = parseCAMT053(data)
+	case FormatMT940:
+		lines, err = parseMT940(data)
+	case FormatCSV:
+		lines, err = parseCSV(data)
+	default:
+		return nil, fmt.Errorf("unsupported statement format %q, expected one of %s", format, strings.Join(Formats(), ", "))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse %s as %s: %w", path, format, err)
+	}
+	return lines, nil
+}
+
+// Detect guesses the format of a statement: XML is taken for CAMT.053 and
+// files with MT940 field tags for MT940. Anything else is read as CSV.
+func Detect(path string, data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("<")) || strings.EqualFold(filepath.Ext(path), ".xml"):
+		return FormatCAMT053
+	case bytes.Contains(trimmed, []byte(":61:")) && bytes.Contains(trimmed, []byte(":20:")):
+		return FormatMT940
+	default:
+		return FormatCSV
+	}
+}
+
+// CleanReference collapses whitespace so references split over several lines
+// of a statement compare equal to their original.
+func CleanReference(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
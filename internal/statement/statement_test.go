@@ -0,0 +1,227 @@
+package statement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestParseCSVAmount(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "", want: "0"},
+		{value: "12.50", want: "12.5"},
+		{value: "12,50", want: "12.5"},
+		{value: "-0,5", want: "-0.5"},
+		{value: "1234.56", want: "1234.56"},
+		{value: "1.234,56", want: "1234.56"},
+		{value: "-1.234,56", want: "-1234.56"},
+		{value: "1,234.56", want: "1234.56"},
+		{value: "1 234,56", want: "1234.56"},
+		{value: "1 234,56", want: "1234.56"},
+		{value: "1'234.56", want: "1234.56"},
+		// A single separator followed by three digits separates thousands.
+		{value: "1,234", want: "1234"},
+		{value: "1.234", want: "1234"},
+		{value: "-1.234", want: "-1234"},
+		// A separator appearing several times separates thousands.
+		{value: "1.234.567", want: "1234567"},
+		{value: "1,234,567", want: "1234567"},
+		{value: "1.234.567,8", want: "1234567.8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseCSVAmount(tt.value)
+			if err != nil {
+				t.Fatalf("parseCSVAmount(%q) returned error: %v", tt.value, err)
+			}
+			if !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("parseCSVAmount(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+
+	for _, value := range []string{"abc", "12.50 EUR", "1,2,3.4.5"} {
+		if _, err := parseCSVAmount(value); err == nil {
+			t.Errorf("parseCSVAmount(%q) succeeded, want an error", value)
+		}
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []Line
+	}{
+		{
+			name: "semicolon with german headers",
+			data: "Buchungstag;Verwendungszweck;Betrag;Währung\n" +
+				"16.10.2026;SumUp Auszahlung 101;1.234,56;eur\n" +
+				"17.10.2026;Miete;-800,00;EUR\n",
+			want: []Line{
+				{Position: 2, Date: date(2026, 10, 16), Amount: decimal.RequireFromString("1234.56"), Currency: "EUR", Reference: "SumUp Auszahlung 101"},
+				{Position: 3, Date: date(2026, 10, 17), Amount: decimal.RequireFromString("-800"), Currency: "EUR", Reference: "Miete"},
+			},
+		},
+		{
+			name: "comma with credit and debit columns",
+			data: "Date,Description,Paid in,Paid out\n" +
+				"2026-10-16,\"SumUp  payout\",\"1,234.00\",\n" +
+				"\n" +
+				"2026-10-17,Card fee,,2.50\n",
+			want: []Line{
+				{Position: 2, Date: date(2026, 10, 16), Amount: decimal.RequireFromString("1234"), Reference: "SumUp payout"},
+				{Position: 4, Date: date(2026, 10, 17), Amount: decimal.RequireFromString("-2.5"), Reference: "Card fee"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCSV([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("parseCSV returned error: %v", err)
+			}
+			assertLines(t, got, tt.want)
+		})
+	}
+}
+
+func TestParseMT940(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want Line
+	}{
+		{
+			name: "credit",
+			line: ":61:2610161016C24,26NTRFNONREF//B123\n:86:166?00GUTSCHRIFT?20SumUp payout?21 102",
+			want: Line{Date: date(2026, 10, 16), Amount: decimal.RequireFromString("24.26"), Reference: "GUTSCHRIFT SumUp payout 102"},
+		},
+		{
+			name: "debit",
+			line: ":61:261016D5,NMSCREF-1",
+			want: Line{Date: date(2026, 10, 16), Amount: decimal.RequireFromString("-5"), Reference: "REF-1"},
+		},
+		{
+			name: "reversal of a credit is a debit",
+			line: ":61:261016RC10,00NTRFNONREF",
+			want: Line{Date: date(2026, 10, 16), Amount: decimal.RequireFromString("-10")},
+		},
+		{
+			name: "reversal of a debit is a credit",
+			line: ":61:261016RD10,00NTRFNONREF",
+			want: Line{Date: date(2026, 10, 16), Amount: decimal.RequireFromString("10")},
+		},
+		{
+			name: "funds code after the mark",
+			line: ":61:261016CR7,50NTRFNONREF",
+			want: Line{Date: date(2026, 10, 16), Amount: decimal.RequireFromString("7.5")},
+		},
+		{
+			name: "entry date in the next year",
+			line: ":61:2612310102C1,00NTRFNONREF",
+			want: Line{Date: date(2027, 1, 2), Amount: decimal.RequireFromString("1")},
+		},
+		{
+			name: "entry date in the previous year",
+			line: ":61:2701021231C1,00NTRFNONREF",
+			want: Line{Date: date(2026, 12, 31), Amount: decimal.RequireFromString("1")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := ":20:STMT\n:25:DE89370400440532013000\n:28C:1/1\n:60F:C261015EUR0,00\n" + tt.line + "\n:62F:C261016EUR0,00\n-"
+			got, err := parseMT940([]byte(data))
+			if err != nil {
+				t.Fatalf("parseMT940 returned error: %v", err)
+			}
+			want := tt.want
+			want.Position = 1
+			want.Currency = "EUR"
+			assertLines(t, got, []Line{want})
+		})
+	}
+
+	if _, err := parseMT940([]byte(":20:STMT\n:61:26101C1,00\n")); err == nil {
+		t.Error("parseMT940 accepted an invalid :61: field")
+	}
+}
+
+func TestParseCAMT053(t *testing.T) {
+	entry := func(indicator, reversal string) string {
+		return `<Ntry><Amt Ccy="EUR">12.50</Amt><CdtDbtInd>` + indicator + `</CdtDbtInd>` + reversal +
+			`<BookgDt><Dt>2026-10-16</Dt></BookgDt><NtryDtls><TxDtls><RmtInf><Ustrd>SumUp 101</Ustrd></RmtInf>` +
+			`<RltdPties><Dbtr><Nm>SumUp Payments</Nm></Dbtr></RltdPties></TxDtls></NtryDtls></Ntry>`
+	}
+	tests := []struct {
+		name  string
+		entry string
+		want  string
+	}{
+		{name: "credit", entry: entry("CRDT", ""), want: "12.5"},
+		{name: "debit", entry: entry("DBIT", ""), want: "-12.5"},
+		// A reversal states its own direction: a debit reversing a credit
+		// still takes money off the account.
+		{name: "debit reversing a credit", entry: entry("DBIT", "<RvslInd>true</RvslInd>"), want: "-12.5"},
+		{name: "credit reversing a debit", entry: entry("CRDT", "<RvslInd>true</RvslInd>"), want: "12.5"},
+		{name: "not reversed", entry: entry("CRDT", "<RvslInd>false</RvslInd>"), want: "12.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := `<?xml version="1.0"?><Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">` +
+				`<BkToCstmrStmt><Stmt><Acct><Ccy>EUR</Ccy></Acct>` + tt.entry + `</Stmt></BkToCstmrStmt></Document>`
+			got, err := parseCAMT053([]byte(data))
+			if err != nil {
+				t.Fatalf("parseCAMT053 returned error: %v", err)
+			}
+			assertLines(t, got, []Line{{
+				Position:     1,
+				Date:         date(2026, 10, 16),
+				Amount:       decimal.RequireFromString(tt.want),
+				Currency:     "EUR",
+				Reference:    "SumUp 101",
+				Counterparty: "SumUp Payments",
+			}})
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		path string
+		data string
+		want string
+	}{
+		{path: "bank.xml", data: "", want: FormatCAMT053},
+		{path: "bank.txt", data: "  <?xml version=\"1.0\"?>", want: FormatCAMT053},
+		{path: "bank.sta", data: ":20:STMT\n:61:261016C1,00NTRFNONREF", want: FormatMT940},
+		{path: "bank.csv", data: "date;amount\n2026-10-16;1,00", want: FormatCSV},
+	}
+	for _, tt := range tests {
+		if got := Detect(tt.path, []byte(tt.data)); got != tt.want {
+			t.Errorf("Detect(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func assertLines(t *testing.T, got, want []Line) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d lines, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if g.Position != w.Position || !g.Date.Equal(w.Date) || !g.Amount.Equal(w.Amount) ||
+			g.Currency != w.Currency || g.Reference != w.Reference || g.Counterparty != w.Counterparty {
+			t.Errorf("line %d = %+v, want %+v", i, g, w)
+		}
+	}
+}
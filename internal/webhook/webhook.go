@@ -0,0 +1,213 @@
+package webhook
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/sumup/sumup-cli/internal/config"
+)
+
+// ErrEventNotFound is returned when the requested event is not in the history.
+var ErrEventNotFound = errors.New("event not found")
+
+// Event is a request captured by the local listener.
+type Event struct {
+	ID         string      `json:"id"`
+	ReceivedAt time.Time   `json:"received_at"`
+	Method     string      `json:"method"`
+	Path       string      `json:"path"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+type listenerState struct {
+	URL string `json:"url"`
+	// PID is the process of the listener, to detect a state file left
+	// behind by a listener that did not shut down.
+	PID int `json:"pid,omitempty"`
+}
+
+const (
+	historyFile = "listen-events.jsonl"
+	// maxHistorySize is the size at which the event history is rotated. The
+	// previous file is kept, so replay finds events from both.
+	maxHistorySize = 10 << 20
+)
+
+// NewEventID returns a random identifier for a captured event.
+func NewEventID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate event ID: %w", err)
+	}
+	return "evt_" + hex.EncodeToString(buf), nil
+}
+
+func statePath(name string) (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// AppendHistory stores the event so that it can be replayed later.
+func AppendHistory(event Event) error {
+	path, err := statePath(historyFile)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create config directory: %w", err)
+	}
+	if info, err := os.Stat(path); err == nil && info.Size() >= maxHistorySize {
+		if err := os.Rename(path, path+".1"); err != nil {
+			return fmt.Errorf("rotate event history: %w", err)
+		}
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("open event history: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write event history: %w", err)
+	}
+	return nil
+}
+
+// FindEvent looks up a previously captured event by its ID, in the current
+// and the rotated event history.
+func FindEvent(id string) (*Event, error) {
+	path, err := statePath(historyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range []string{path, path + ".1"} {
+		event, err := findEvent(name, id)
+		if !errors.Is(err, ErrEventNotFound) {
+			return event, err
+		}
+	}
+	return nil, ErrEventNotFound
+}
+
+func findEvent(path, id string) (*Event, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("open event history: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		if event.ID == id {
+			return &event, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read event history: %w", err)
+	}
+
+	return nil, ErrEventNotFound
+}
+
+// SetListenerURL records the URL of the running listener so that other
+// commands can use it as their return URL.
+func SetListenerURL(url string) error {
+	path, err := statePath("listen.json")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create config directory: %w", err)
+	}
+
+	data, err := json.Marshal(listenerState{URL: url, PID: os.Getpid()})
+	if err != nil {
+		return fmt.Errorf("marshal listener state: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write listener state: %w", err)
+	}
+	return nil
+}
+
+// ClearListenerURL removes the recorded listener URL.
+func ClearListenerURL() error {
+	path, err := statePath("listen.json")
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove listener state: %w", err)
+	}
+	return nil
+}
+
+// ListenerURL returns the URL of the running listener.
+func ListenerURL() (string, error) {
+	path, err := statePath("listen.json")
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", errors.New("no listener is running. Start one with 'sumup listen'")
+		}
+		return "", fmt.Errorf("read listener state: %w", err)
+	}
+
+	var state listenerState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return "", fmt.Errorf("parse listener state: %w", err)
+	}
+	if state.PID != 0 && !processRunning(state.PID) {
+		_ = os.Remove(path)
+		return "", errors.New("the last listener exited without cleaning up and no listener is running. Start one with 'sumup listen'")
+	}
+	return state.URL, nil
+}
+
+// processRunning reports whether the process exists. Signal 0 only checks
+// for the process on Unix; Windows cannot send it, but there finding the
+// process already fails once it has exited.
+func processRunning(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	err = process.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM) || runtime.GOOS == "windows"
+}